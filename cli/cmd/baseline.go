@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/baseline"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	baselineCheckers []string
+)
+
+// baselineCmd represents the baseline command
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the baseline of known issues",
+	Long: `Manage the baseline of known issues.
+
+A baseline is a snapshot of the issues that exist today. When a baseline file
+is present, Marvin still reports the known issues but only new errors make a
+check fail. This lets you adopt Marvin as a gate on docs with many existing
+issues and fix them over time.
+
+The baseline file defaults to .marvin-baseline.json and should be committed.
+Issues are matched by fingerprint: a hash of the checker, rule, file path,
+flagged text and the text of the line the issue is on. Line numbers are not
+part of it, so an issue stays known when lines are added or removed elsewhere
+in the file. Editing the line itself, or moving or renaming the file, makes
+the issue new. Baselines without fingerprints are matched by checker, file,
+rule and flagged text.`,
+	Example: `  # Snapshot the current issues of all enabled checkers
+  marvin baseline create
+
+  # Ignore the baseline for one run
+  marvin check --no-baseline`,
+}
+
+// baselineCreateCmd represents the baseline create command
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create [paths...]",
+	Short: "Snapshot the current issues into the baseline file",
+	Long: `Run all enabled checkers and save every issue they report to the
+baseline file, replacing any existing baseline.
+
+The checkers and paths are selected the same way as for "marvin check".
+Every file is checked: cached results are not reused, and --changed-since,
+--staged and --only-changed-lines are rejected, because a baseline of only
+some files would make the other known issues new.`,
+	RunE: runBaselineCreate,
+	Example: `  # Create the baseline from all enabled checkers
+  marvin baseline create
+
+  # Create a baseline for Vale only
+  marvin baseline create --checkers vale
+
+  # Write the baseline to a different file
+  marvin baseline create --baseline docs/.marvin-baseline.json`,
+}
+
+func init() {
+	rootCmd.AddCommand(baselineCmd)
+	baselineCmd.AddCommand(baselineCreateCmd)
+
+	// Command-specific flags
+	baselineCreateCmd.Flags().StringSliceVar(&baselineCheckers, "checkers", nil,
+		"Comma-separated list of checkers to include (default: all enabled checkers)")
+}
+
+func runBaselineCreate(cmd *cobra.Command, args []string) error {
+	// 1. Run checkers on all files, without applying the old baseline; the
+	// git change flags were rejected by loadChanges
+	knownIssues = nil
+	noCache = true
+	outcomes, err := runEnabledCheckers(cmd, args, baselineCheckers)
+	if err != nil {
+		return err
+	}
+
+	// 2. Collect results; a partial baseline would hide new issues later
+	var results []*models.Result
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", outcome.name, outcome.err)
+			err := fmt.Errorf("baseline not created: %s check failed", outcome.name)
+			if code, _ := exitCode(outcome.err); code == ExitConfigError {
+				return configError(err)
+			}
+			return toolError(err)
+		}
+		results = append(results, outcome.result)
+	}
+
+	// 3. Save baseline
+	b := baseline.New(results)
+	if err := b.Save(baselineFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Baseline saved to: %s (%d known issues from %d checkers)\n", baselineFile, b.Len(), len(results))
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/checker"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	checkCheckers []string
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check [paths...]",
+	Short: "Run all enabled checkers",
+	Long: `Run every enabled checker in a single invocation.
+
+The checkers run concurrently. Each result is saved to the output directory
+as usual, and the combined results are shown in the dashboard TUI by default,
+or as plain text or JSON.
+
+Checkers can be disabled in .marvin.yaml with "enabled: false", or selected
+explicitly with --checkers. When no paths are given, each checker scans its
+configured default path (docs/ unless set in .marvin.yaml).
+
+The command exits with code 1 if the quality gate fails (by default, on any
+error that is not in the baseline) and with code 2 if a checker fails to run.`,
+	RunE: runCheckAll,
+	Example: `  # Run all enabled checkers on their default paths
+  marvin check
+
+  # Run all enabled checkers on specific directories
+  marvin check ./docs ./content
+
+  # Run only Vale and markdownlint, output plain text
+  marvin check --checkers vale,markdownlint --no-tui`,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	// Command-specific flags
+	checkCmd.Flags().StringSliceVar(&checkCheckers, "checkers", nil,
+		"Comma-separated list of checkers to run (default: all enabled checkers)")
+}
+
+// checkOutcome holds the result of a single checker run
+type checkOutcome struct {
+	name       string
+	plan       *checkPlan
+	result     *models.Result
+	outputPath string
+	err        error
+}
+
+// checkPlan is a checker that is ready to run
+type checkPlan struct {
+	def      checker.Definition
+	settings checker.Settings
+	checker  checker.Checker
+	opts     checker.CheckOptions
+}
+
+func runCheckAll(cmd *cobra.Command, args []string) error {
+	// 1. Run the selected or enabled checkers
+	outcomes, err := runEnabledCheckers(cmd, args, checkCheckers)
+	if err != nil {
+		return err
+	}
+
+	// 2. Write report and display combined output
+	var results []*models.Result
+	var outputPaths []string
+	failed := 0
+	invalid := false
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			failed++
+			if code, _ := exitCode(outcome.err); code == ExitConfigError {
+				invalid = true
+			}
+			fmt.Fprintf(os.Stderr, "%s: %v\n", outcome.name, outcome.err)
+			continue
+		}
+		results = append(results, outcome.result)
+		outputPaths = append(outputPaths, outcome.outputPath)
+	}
+
+	if err := writeReport(results); err != nil {
+		return err
+	}
+	if err := displayResults(results, outputPaths); err != nil {
+		return err
+	}
+
+	// 3. Evaluate the quality gate; a failed checker takes precedence, and
+	// invalid configuration or paths over a failed tool
+	gateErr := evaluateGate(results)
+	if invalid {
+		return configError(fmt.Errorf("%d of %d checkers failed", failed, len(outcomes)))
+	}
+	if failed > 0 {
+		return toolError(fmt.Errorf("%d of %d checkers failed", failed, len(outcomes)))
+	}
+
+	return gateErr
+}
+
+// runEnabledCheckers runs the named checkers, or all enabled checkers if
+// names is empty, concurrently on paths. Each checker scans its default path
+// when no paths are given.
+func runEnabledCheckers(cmd *cobra.Command, paths []string, names []string) ([]*checkOutcome, error) {
+	outcomes, err := planChecks(cmd, paths, names)
+	if err != nil {
+		return nil, err
+	}
+
+	title := "Checking default paths"
+	if len(paths) > 0 {
+		title = "Checking " + strings.Join(paths, ", ")
+	}
+	err = withProgress(cmd.Context(), title, func(ctx context.Context, progress checker.ProgressFunc) error {
+		runPlans(outcomes, func(plan *checkPlan) (*models.Result, string, error) {
+			opts := plan.opts
+			opts.Progress = progress
+			return runCheck(ctx, plan.def, plan.settings, plan.checker, opts)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// planChecks creates the named checkers, or all enabled checkers if names is
+// empty, for paths. Checkers that cannot be created have an error in their
+// outcome instead of a plan.
+func planChecks(cmd *cobra.Command, paths []string, names []string) ([]*checkOutcome, error) {
+	// 1. Select checkers
+	selected := make(map[string]bool)
+	for _, name := range names {
+		selected[strings.TrimSpace(name)] = true
+	}
+	for name := range selected {
+		if _, ok := checker.Lookup(name); !ok {
+			return nil, fmt.Errorf("unknown checker: %s", name)
+		}
+	}
+
+	// 2. Create checkers
+	outcomes := []*checkOutcome{}
+	for _, def := range checker.Definitions() {
+		if len(selected) > 0 {
+			if !selected[def.Name] {
+				continue
+			}
+		} else if !cfg.Checker(def.Name).IsEnabled() {
+			continue
+		}
+
+		outcome := &checkOutcome{name: def.Name}
+		outcomes = append(outcomes, outcome)
+
+		checkerPaths := paths
+		if len(checkerPaths) == 0 {
+			checkerPaths = []string{resolvePath(def, nil)}
+		}
+		if err := checkPathsExist(checkerPaths); err != nil {
+			outcome.err = configError(err)
+			continue
+		}
+
+		c, settings, err := newChecker(cmd, def, nil)
+		if err != nil {
+			outcome.err = err
+			continue
+		}
+
+		outcome.plan = &checkPlan{
+			def:      def,
+			settings: settings,
+			checker:  c,
+			opts: checker.CheckOptions{
+				Path:       strings.Join(checkerPaths, " "),
+				Paths:      checkerPaths,
+				ConfigFile: settings.String("config"),
+			},
+		}
+	}
+
+	if len(outcomes) == 0 {
+		return nil, fmt.Errorf("no checkers enabled")
+	}
+
+	return outcomes, nil
+}
+
+// runPlans runs the planned checkers concurrently and stores what run
+// returns in their outcomes
+func runPlans(outcomes []*checkOutcome, run func(plan *checkPlan) (*models.Result, string, error)) {
+	var wg sync.WaitGroup
+	for _, outcome := range outcomes {
+		if outcome.plan == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(outcome *checkOutcome) {
+			defer wg.Done()
+			outcome.result, outcome.outputPath, outcome.err = run(outcome.plan)
+		}(outcome)
+	}
+	wg.Wait()
+}
+
+// checkPathsExist returns an error for the first path that does not exist
+func checkPathsExist(paths []string) error {
+	for _, path := range paths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return fmt.Errorf("path does not exist: %s", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/checker"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	checkersJSON bool
+)
+
+// checkersCmd represents the checkers command
+var checkersCmd = &cobra.Command{
+	Use:   "checkers",
+	Short: "List available checkers",
+	Long: `List all checkers known to Marvin together with the external tool
+each one requires.
+
+Use --json to get a machine-readable list, for example to validate checker
+names in the web dashboard.`,
+	Args: cobra.NoArgs,
+	RunE: runCheckers,
+	Example: `  # List available checkers
+  marvin checkers
+
+  # List available checkers as JSON
+  marvin checkers --json`,
+}
+
+// checkerFlags holds the values of the flags registered for a checker command
+type checkerFlags struct {
+	strings map[string]*string
+	bools   map[string]*bool
+}
+
+// checkerInfo is the JSON representation of a checker definition
+type checkerInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Tool        string `json:"tool,omitempty"`
+	Plugin      string `json:"plugin,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func init() {
+	rootCmd.AddCommand(checkersCmd)
+	checkersCmd.Flags().BoolVar(&checkersJSON, "json", false, "Output the checker list as JSON")
+}
+
+// addCheckerCommands registers external checker plugins and adds a command
+// for every registered checker. It runs after all other commands have been
+// added so that plugins cannot shadow them.
+func addCheckerCommands() {
+	reserved := make(map[string]bool)
+	for _, c := range rootCmd.Commands() {
+		reserved[c.Name()] = true
+	}
+	reserved["help"] = true
+	reserved["completion"] = true
+
+	checker.RegisterPlugins(reserved)
+
+	for _, def := range checker.Definitions() {
+		rootCmd.AddCommand(newCheckerCommand(def))
+	}
+}
+
+// newCheckerCommand creates the cobra command for a registered checker
+func newCheckerCommand(def checker.Definition) *cobra.Command {
+	flags := &checkerFlags{
+		strings: make(map[string]*string),
+		bools:   make(map[string]*bool),
+	}
+
+	cmd := &cobra.Command{
+		Use:     def.Name + " [path]",
+		Short:   def.Short,
+		Long:    def.Long,
+		Example: def.Example,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChecker(cmd, def, flags, args)
+		},
+	}
+
+	// Command-specific flags
+	for _, flag := range def.Flags {
+		switch flag.Kind {
+		case checker.BoolFlag:
+			value, _ := strconv.ParseBool(flag.Default)
+			flags.bools[flag.Name] = cmd.Flags().Bool(flag.Name, value, flag.Usage)
+		default:
+			flags.strings[flag.Name] = cmd.Flags().String(flag.Name, flag.Default, flag.Usage)
+		}
+	}
+
+	return cmd
+}
+
+func runChecker(cmd *cobra.Command, def checker.Definition, flags *checkerFlags, args []string) error {
+	// 1. Parse arguments
+	path := resolvePath(def, args)
+
+	// Check if path exists
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return configError(fmt.Errorf("path does not exist: %s", path))
+	}
+
+	if verbose {
+		fmt.Fprintf(logOutput, "Scanning path: %s\n", path)
+	}
+
+	// 2. Check dependencies and create checker
+	c, settings, err := newChecker(cmd, def, flags)
+	if err != nil {
+		return err
+	}
+
+	// 3. Run checker and save results
+	var result *models.Result
+	var outputPath string
+	err = withProgress(cmd.Context(), "Checking "+path, func(ctx context.Context, progress checker.ProgressFunc) error {
+		var err error
+		result, outputPath, err = runCheck(ctx, def, settings, c, checker.CheckOptions{
+			Path:       path,
+			ConfigFile: settings.String("config"),
+			Progress:   progress,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// 4. Write report and display output
+	if err := writeReport([]*models.Result{result}); err != nil {
+		return err
+	}
+	if err := displayResult(result, outputPath); err != nil {
+		return err
+	}
+
+	// 5. Evaluate the quality gate
+	return evaluateGate([]*models.Result{result})
+}
+
+// newChecker resolves the settings for a checker, checks that its external
+// tool is installed and creates and validates the checker. flags may be nil
+// when the checker runs from a command without checker-specific flags.
+func newChecker(cmd *cobra.Command, def checker.Definition, flags *checkerFlags) (checker.Checker, checker.Settings, error) {
+	settings := resolveSettings(cmd, def, flags)
+
+	// Auto-detect the tool config file if not specified
+	if settings.String("config") == "" && len(def.ConfigFiles) > 0 {
+		if found := findConfigFile(def.ConfigFiles); found != "" {
+			settings.Values["config"] = found
+			if verbose {
+				fmt.Fprintf(logOutput, "Auto-detected config file: %s\n", found)
+			}
+		}
+	}
+
+	if verbose && settings.String("config") != "" {
+		fmt.Fprintf(logOutput, "Using config file: %s\n", settings.String("config"))
+	}
+
+	// Check dependencies
+	if def.Tool != "" {
+		detector := newDetector()
+		installed, toolPath, _ := detector.IsInstalled(def.Tool)
+		if !installed {
+			// The language server reports the error in its log instead
+			if !lspMode {
+				fmt.Println(detector.GetInstallInstructions(def.Tool))
+			}
+			return nil, settings, toolError(fmt.Errorf("%s not found", def.Tool))
+		}
+
+		if verbose {
+			fmt.Fprintf(logOutput, "Found %s at: %s\n", def.Tool, toolPath)
+		}
+		settings.ToolPath = toolPath
+	}
+
+	c, err := def.New(settings)
+	if err != nil {
+		return nil, settings, configError(fmt.Errorf("failed to create %s checker: %w", def.Name, err))
+	}
+
+	// Validate checker
+	if err := c.Validate(); err != nil {
+		return nil, settings, configError(fmt.Errorf("%s validation failed: %w", def.Name, err))
+	}
+
+	return c, settings, nil
+}
+
+// resolveSettings determines the value of each checker flag. Flags set on the
+// command line take precedence over .marvin.yaml, which takes precedence over
+// the flag default.
+func resolveSettings(cmd *cobra.Command, def checker.Definition, flags *checkerFlags) checker.Settings {
+	defaults := cfg.Checker(def.Name)
+	settings := checker.Settings{
+		Values: make(map[string]string),
+	}
+
+	for _, flag := range def.Flags {
+		value := flag.Default
+		if configured, ok := defaults.Value(flag.Name); ok {
+			value = configured
+		}
+
+		if flags != nil && cmd.Flags().Changed(flag.Name) {
+			if s, ok := flags.strings[flag.Name]; ok {
+				value = *s
+			}
+			if b, ok := flags.bools[flag.Name]; ok {
+				value = strconv.FormatBool(*b)
+			}
+		}
+
+		settings.Values[flag.Name] = value
+	}
+
+	return settings
+}
+
+// findConfigFile searches the current directory and its parents for the
+// first existing config file
+func findConfigFile(configFiles []string) string {
+	// Search in current directory and parent directories
+	searchPaths := []string{
+		".",     // Current directory
+		"..",    // Parent directory (project root when running from cli/)
+		"../..", // Grandparent directory (in case of deeper nesting)
+	}
+
+	for _, searchPath := range searchPaths {
+		for _, configFile := range configFiles {
+			fullPath := filepath.Join(searchPath, configFile)
+			if _, err := os.Stat(fullPath); err == nil {
+				return fullPath
+			}
+		}
+	}
+
+	return ""
+}
+
+func runCheckers(cmd *cobra.Command, args []string) error {
+	var infos []checkerInfo
+	for _, def := range checker.Definitions() {
+		infos = append(infos, checkerInfo{
+			Name:        def.Name,
+			Description: def.Short,
+			Tool:        def.Tool,
+			Plugin:      def.Plugin,
+			Enabled:     cfg.Checker(def.Name).IsEnabled(),
+		})
+	}
+
+	if checkersJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(infos); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+
+	for _, info := range infos {
+		tool := info.Tool
+		if info.Plugin != "" {
+			tool = "plugin: " + info.Plugin
+		} else if tool == "" {
+			tool = "built-in"
+		}
+		status := ""
+		if !info.Enabled {
+			status = " (disabled)"
+		}
+		fmt.Printf("%-14s %-40s [%s]%s\n", info.Name, info.Description, tool, status)
+	}
+
+	return nil
+}
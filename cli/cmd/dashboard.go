@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/svx/marvin/cli/internal/app/dashboard"
+	"github.com/svx/marvin/cli/internal/app/output"
 	"github.com/svx/marvin/cli/internal/app/tui"
 )
 
@@ -18,13 +20,19 @@ The dashboard reads all check results from the output directory and displays
 them in an interactive TUI with summary statistics and drill-down capabilities.
 
 You can navigate between different checkers using Tab/Shift+Tab and view
-detailed results by pressing Enter.`,
+detailed results by pressing Enter.
+
+With --format template, the dashboard data is written with a template
+instead, including the run statistics of all saved results.`,
 	RunE: runDashboard,
 	Example: `  # Show dashboard with all results
   marvin dashboard
 
   # Show dashboard with custom output directory
-  marvin dashboard --output-dir ./custom-results`,
+  marvin dashboard --output-dir ./custom-results
+
+  # Write the dashboard with a built-in template
+  marvin dashboard --format template --template summary`,
 }
 
 func init() {
@@ -54,7 +62,16 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// 2. Display dashboard in TUI
+	// 2. Write the dashboard with a template if requested
+	if outputFormat() == "template" {
+		formatter, err := output.NewTemplateFormatter(templateFile, formatOptions(true).Color)
+		if err != nil {
+			return err
+		}
+		return formatter.FormatDashboard(data, os.Stdout)
+	}
+
+	// 3. Display dashboard in TUI
 	if err := tui.ShowDashboard(data); err != nil {
 		return fmt.Errorf("failed to show dashboard: %w", err)
 	}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/dashboard"
+	"github.com/svx/marvin/cli/internal/app/diff"
+	"github.com/svx/marvin/cli/internal/app/output"
+	"github.com/svx/marvin/cli/internal/app/tui"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	diffChecker       string
+	diffShowUnchanged bool
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [old] [new]",
+	Short: "Compare two check results",
+	Long: `Compare two saved check results and show which issues are new, fixed
+or unchanged, with the change in issue counts per rule and per file.
+
+Each result can be a path to a result JSON file, or a reference to a result
+in the output directory:
+  latest            The newest result of the checker
+  previous          The result before the newest one
+  vale:latest       The newest Vale result (likewise vale:previous)
+
+Use --checker to select the checker for latest and previous. Without
+arguments, the previous result is compared with the latest one.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runDiff,
+	Example: `  # What changed between the last two Vale runs
+  marvin diff --checker vale
+
+  # Compare two result files
+  marvin diff .marvin/results/vale-20260101-120000.json .marvin/results/vale-20260102-120000.json
+
+  # Compare a result from main with the latest run
+  marvin diff main-vale.json vale:latest
+
+  # Output the comparison as JSON
+  marvin diff previous latest --checker markdownlint --json
+
+  # Post new and fixed issues as a pull request comment
+  marvin diff main-vale.json vale:latest --format markdown > comment.md`,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	// Command-specific flags
+	diffCmd.Flags().StringVar(&diffChecker, "checker", "", "Checker for latest and previous references")
+	diffCmd.Flags().BoolVar(&diffShowUnchanged, "unchanged", false, "Also list unchanged issues in text output")
+}
+
+// resultRef is a resolved reference to a saved result
+type resultRef struct {
+	result *models.Result
+	file   string
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	// 1. Parse arguments
+	oldArg, newArg := "previous", "latest"
+	switch len(args) {
+	case 1:
+		oldArg = args[0]
+	case 2:
+		oldArg, newArg = args[0], args[1]
+	}
+
+	// 2. Load results. Files are loaded first so their checker can be
+	// used for latest and previous references.
+	refs := make([]*resultRef, 2)
+	checkerName := diffChecker
+	for i, arg := range []string{oldArg, newArg} {
+		if !isResultFile(arg) {
+			continue
+		}
+		result, err := dashboard.ParseResultFile(arg)
+		if err != nil {
+			return err
+		}
+		refs[i] = &resultRef{result: result, file: arg}
+		if checkerName == "" {
+			checkerName = result.Checker
+		}
+	}
+	for i, arg := range []string{oldArg, newArg} {
+		if refs[i] != nil {
+			continue
+		}
+		ref, err := resolveResultRef(arg, checkerName)
+		if err != nil {
+			return err
+		}
+		refs[i] = ref
+	}
+
+	oldRef, newRef := refs[0], refs[1]
+	if oldRef.result.Checker != newRef.result.Checker {
+		return fmt.Errorf("cannot compare results of different checkers: %s and %s", oldRef.result.Checker, newRef.result.Checker)
+	}
+
+	// 3. Compare
+	report := diff.Compare(oldRef.result, newRef.result, oldRef.file, newRef.file)
+
+	// 4. Display output
+	switch name := outputFormat(); name {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	case "text", "github":
+		if err := report.WriteText(os.Stdout, diffShowUnchanged); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+	case "markdown":
+		if err := output.NewMarkdownFormatter(output.DefaultMarkdownLimit).FormatDiff(report, os.Stdout); err != nil {
+			return err
+		}
+	case "":
+		if err := tui.ShowDiff(report); err != nil {
+			return fmt.Errorf("failed to show TUI: %w", err)
+		}
+	default:
+		return fmt.Errorf("diff does not support the %s format (use text, json or markdown)", name)
+	}
+
+	return nil
+}
+
+// isResultFile reports whether arg names an existing file rather than a
+// latest or previous reference
+func isResultFile(arg string) bool {
+	info, err := os.Stat(arg)
+	return err == nil && !info.IsDir()
+}
+
+// resolveResultRef loads the result for a latest or previous reference,
+// optionally qualified with a checker name as in vale:latest
+func resolveResultRef(ref, checkerName string) (*resultRef, error) {
+	name := ref
+	if i := strings.LastIndex(ref, ":"); i >= 0 {
+		checkerName, name = ref[:i], ref[i+1:]
+	}
+
+	var index int
+	switch name {
+	case "latest":
+		index = 0
+	case "previous":
+		index = 1
+	default:
+		return nil, fmt.Errorf("result not found: %s (use a file path, latest or previous)", ref)
+	}
+	if checkerName == "" {
+		return nil, fmt.Errorf("use --checker or a reference like vale:%s to select the checker", name)
+	}
+
+	files, err := dashboard.CheckerResultFiles(outputDir, checkerName)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(files) {
+		return nil, fmt.Errorf("no %s result for %s in %s (found %d)", name, checkerName, outputDir, len(files))
+	}
+
+	result, err := dashboard.ParseResultFile(files[index])
+	if err != nil {
+		return nil, err
+	}
+	return &resultRef{result: result, file: files[index]}, nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+)
+
+// Exit codes
+const (
+	// ExitIssues means the check ran but the quality gate failed
+	ExitIssues = 1
+
+	// ExitToolFailed means a checker or its external tool failed to run
+	ExitToolFailed = 2
+
+	// ExitConfigError means the configuration, flags or arguments are invalid
+	ExitConfigError = 3
+)
+
+// exitError is an error that carries the process exit code
+type exitError struct {
+	code int
+	err  error
+
+	// silent errors have already been reported and are not printed again
+	silent bool
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// toolError marks err as a failure to run a checker
+func toolError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: ExitToolFailed, err: err}
+}
+
+// configError marks err as invalid configuration
+func configError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: ExitConfigError, err: err}
+}
+
+// issuesFound returns the error for a failed quality gate. The gate report
+// has already been shown, so the error is not printed.
+func issuesFound(err error) error {
+	return &exitError{code: ExitIssues, err: err, silent: true}
+}
+
+// exitCode returns the exit code for an error returned by a command.
+// Errors without a code come from cobra's flag and argument validation.
+func exitCode(err error) (int, bool) {
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code, exitErr.silent
+	}
+	return ExitConfigError, false
+}
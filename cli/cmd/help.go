@@ -5,6 +5,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/checker"
 )
 
 var (
@@ -60,39 +61,37 @@ func showMainHelp() {
 	fmt.Println("  marvin [command] [flags]")
 	fmt.Println()
 
-	// Available Commands
-	fmt.Println(sectionStyle.Render("Available Commands:"))
-	
-	commands := []struct {
-		name string
-		desc string
-	}{
-		{"vale", "Run Vale prose linting on documentation"},
-		{"markdownlint", "Run markdownlint on Markdown files"},
-		{"dashboard", "View aggregated results from all checks"},
-		{"help", "Help about any command"},
+	// Checkers, derived from the checker registry
+	fmt.Println(sectionStyle.Render("Checkers:"))
+	for _, def := range checker.Definitions() {
+		printCommand(def.Name, def.Short)
 	}
+	fmt.Println()
 
-	for _, c := range commands {
-		fmt.Printf("  %s  %s\n", 
-			commandStyle.Render(fmt.Sprintf("%-12s", c.name)),
-			descStyle.Render(c.desc))
+	// Other commands
+	fmt.Println(sectionStyle.Render("Available Commands:"))
+	listed := make(map[string]bool)
+	for _, c := range rootCmd.Commands() {
+		if _, isChecker := checker.Lookup(c.Name()); isChecker || c.Hidden || c.Name() == "completion" || listed[c.Name()] {
+			continue
+		}
+		listed[c.Name()] = true
+		printCommand(c.Name(), c.Short)
 	}
 	fmt.Println()
 
-	// Global Flags
+	// Global flags, derived from the root command so the list stays current
 	fmt.Println(sectionStyle.Render("Global Flags:"))
-	fmt.Println("  --output-dir string   Output directory for JSON results (default \".marvin/results\")")
-	fmt.Println("  --no-tui              Disable TUI, output plain text to stdout")
-	fmt.Println("  --json                Output raw JSON to stdout (implies --no-tui)")
-	fmt.Println("  --verbose             Enable verbose logging")
-	fmt.Println("  --config string       Path to config file (default \".marvin.yaml\")")
-	fmt.Println("  -h, --help            Help for marvin")
-	fmt.Println("  -v, --version         Version for marvin")
+	rootCmd.InitDefaultHelpFlag()
+	rootCmd.InitDefaultVersionFlag()
+	fmt.Print(rootCmd.LocalFlags().FlagUsages())
 	fmt.Println()
 
 	// Examples
 	fmt.Println(sectionStyle.Render("Examples:"))
+	fmt.Println("  # Run all enabled checkers")
+	fmt.Println("  marvin check")
+	fmt.Println()
 	fmt.Println("  # Run Vale on default docs/ directory")
 	fmt.Println("  marvin vale")
 	fmt.Println()
@@ -108,6 +107,9 @@ func showMainHelp() {
 	fmt.Println("  # Output JSON only")
 	fmt.Println("  marvin vale --json")
 	fmt.Println()
+	fmt.Println("  # Write SARIF for GitHub code scanning")
+	fmt.Println("  marvin check --format sarif > marvin.sarif")
+	fmt.Println()
 	fmt.Println("  # Get help for a specific command")
 	fmt.Println("  marvin help vale")
 	fmt.Println()
@@ -115,3 +117,10 @@ func showMainHelp() {
 	// Footer
 	fmt.Println("Use \"marvin [command] --help\" for more information about a command.")
 }
+
+// printCommand prints a single entry of a command listing
+func printCommand(name, desc string) {
+	fmt.Printf("  %s  %s\n",
+		commandStyle.Render(fmt.Sprintf("%-12s", name)),
+		descStyle.Render(desc))
+}
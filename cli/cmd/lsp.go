@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/discovery"
+	"github.com/svx/marvin/cli/internal/app/lsp"
+	"github.com/svx/marvin/cli/internal/app/suppress"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	lspCheckers []string
+	lspDelay    time.Duration
+)
+
+// lspCmd represents the lsp command
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server for editor diagnostics",
+	Long: `Run a Language Server Protocol server over stdin and stdout.
+
+Editors such as VS Code, Neovim and Zed start this command to show the issues
+found by Marvin's checkers as diagnostics while you write. Documents are
+checked when they are opened or saved, and after each change once typing
+pauses for --delay. The unsaved text of the editor is checked, not the file
+on disk.
+
+Where a checker supplies a fix, such as a Vale substitution or a fixable
+markdownlint rule, the editor offers it as a quick fix code action.
+
+The server reads .marvin.yaml, .marvinignore and the checker configs from the
+directory it is started in, so start it in the root of the documentation
+project. Ignored files get no diagnostics.`,
+	Args: cobra.NoArgs,
+	RunE: runLSP,
+	Example: `  # Start the language server (usually done by the editor)
+  marvin lsp
+
+  # Only show Vale and markdownlint issues
+  marvin lsp --checkers vale,markdownlint`,
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+
+	// Command-specific flags
+	lspCmd.Flags().StringSliceVar(&lspCheckers, "checkers", nil,
+		"Comma-separated list of checkers to run (default: all enabled checkers)")
+	lspCmd.Flags().DurationVar(&lspDelay, "delay", lsp.DefaultDelay,
+		"How long to wait after a change before checking")
+}
+
+func runLSP(cmd *cobra.Command, args []string) error {
+	// 1. Create the checkers. stdout belongs to the protocol, so problems
+	// go to stderr, which editors show in their server log.
+	outcomes, err := planChecks(cmd, nil, lspCheckers)
+	if err != nil {
+		return err
+	}
+
+	var plans []*checkPlan
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", outcome.name, outcome.err)
+			continue
+		}
+		plans = append(plans, outcome.plan)
+	}
+	if len(plans) == 0 {
+		return toolError(fmt.Errorf("no checkers to run"))
+	}
+
+	// 2. Serve until the editor exits
+	server := lsp.NewServer(lintDocument(plans), lspDelay)
+	if err := server.Run(cmd.Context(), os.Stdin, os.Stdout); err != nil {
+		return toolError(fmt.Errorf("language server failed: %w", err))
+	}
+	return nil
+}
+
+// lintDocument returns a function that runs the planned checkers on the
+// content of a document. The content is written to a hidden temporary file
+// next to the document, so that relative links and config lookups behave
+// as for the document itself.
+func lintDocument(plans []*checkPlan) lsp.LintFunc {
+	return func(ctx context.Context, path string, content []byte) ([]*models.Result, error) {
+		// The matcher reads each directory's ignore files only once
+		if fileFilter != nil {
+			if err := fileFilter.LoadParents(filepath.Dir(path)); err != nil {
+				return nil, fmt.Errorf("failed to load ignore files: %w", err)
+			}
+			if fileFilter.Ignored(path, false) {
+				return nil, nil
+			}
+		}
+
+		// 1. Write the content to a temporary file
+		tmp, err := os.CreateTemp(filepath.Dir(path), ".marvin-lsp-*"+filepath.Ext(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		_, err = tmp.Write(content)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to write temporary file: %w", err)
+		}
+
+		// 2. Run the checkers that check this kind of file
+		var results []*models.Result
+		var errs []error
+		for _, plan := range plans {
+			if !discovery.HasExtension(path, fileExtensions(plan.def)) {
+				continue
+			}
+
+			opts := plan.opts
+			opts.Path = path
+			opts.Files = []string{tmp.Name()}
+			result, err := plan.checker.Check(ctx, opts)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s check failed: %w", plan.def.Name, err))
+				continue
+			}
+
+			// 3. Report the issues of the temporary file for the document
+			suppress.Apply(result, false)
+			issues := []models.Issue{}
+			for _, issue := range result.Issues {
+				if absPath(issue.File) != absPath(tmp.Name()) {
+					continue
+				}
+				issue.File = path
+				issues = append(issues, issue)
+			}
+			result.Issues = issues
+			result.Recount()
+			results = append(results, result)
+		}
+
+		return results, errors.Join(errs...)
+	}
+}
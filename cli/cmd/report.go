@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/dashboard"
+	"github.com/svx/marvin/cli/internal/app/output"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	reportOutput       string
+	reportTitle        string
+	reportSnippetLines int
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate shareable reports from saved results",
+	Long: `Generate reports from the results saved in the output directory, to share
+them with people who do not run Marvin.`,
+	Example: `  # Write an HTML report of the latest results
+  marvin report html`,
+}
+
+// reportHTMLCmd represents the report html command
+var reportHTMLCmd = &cobra.Command{
+	Use:   "html [results...]",
+	Short: "Write a self-contained HTML report",
+	Long: `Write the results as a single HTML file with the styles and scripts
+inlined, so it can be opened in any browser or attached to a CI run.
+
+The report has an overview of all checkers and a tab per checker with the
+issues grouped by file, source snippets around each issue and filters by
+severity, rule and file. Snippets are read from the files as they are when
+the report is written.
+
+Without arguments, the report shows the latest result of each checker in the
+output directory. Otherwise each argument is a result JSON file, or a
+reference like vale:latest or vale:previous, of a different checker.`,
+	RunE: runReportHTML,
+	Example: `  # Report the latest results of all checkers
+  marvin report html
+
+  # Report specific results to a custom file
+  marvin report html vale:latest markdownlint:latest -o docs-report.html
+
+  # Report a result file without source snippets
+  marvin report html .marvin/results/vale-20260101-120000.json --snippet-lines -1
+
+  # Write the report of a check run directly
+  marvin check --report-file report.html --report-format html`,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportHTMLCmd)
+
+	// Command-specific flags
+	reportHTMLCmd.Flags().StringVarP(&reportOutput, "output", "o", "marvin-report.html", "File to write the report to, or - for stdout")
+	reportHTMLCmd.Flags().StringVar(&reportTitle, "title", output.DefaultHTMLTitle, "Title of the report")
+	reportHTMLCmd.Flags().IntVar(&reportSnippetLines, "snippet-lines", output.DefaultSnippetLines,
+		"Source lines shown before and after each issue (-1 disables snippets)")
+}
+
+func runReportHTML(cmd *cobra.Command, args []string) error {
+	// 1. Load results
+	data, err := loadReportData(args)
+	if err != nil {
+		return err
+	}
+	if data.TotalChecks == 0 {
+		fmt.Println("No check results found.")
+		fmt.Printf("Results are stored in: %s\n", outputDir)
+		return nil
+	}
+
+	// 2. Write the report
+	formatter := output.NewHTMLFormatter(reportTitle, reportSnippetLines)
+	if reportOutput == "-" {
+		return formatter.FormatDashboard(data, os.Stdout)
+	}
+
+	if dir := filepath.Dir(reportOutput); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	file, err := os.Create(reportOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
+	if err := formatter.FormatDashboard(data, file); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+
+	fmt.Printf("Report saved to: %s\n", reportOutput)
+	return nil
+}
+
+// loadReportData loads the results named by args, or all results in the
+// output directory if there are none. The report has one section per
+// checker, so args must name results of different checkers.
+func loadReportData(args []string) (*models.DashboardData, error) {
+	if len(args) == 0 {
+		data, err := dashboard.LoadDashboardData(outputDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dashboard data: %w", err)
+		}
+		return data, nil
+	}
+
+	var results []*models.Result
+	seen := make(map[string]string)
+	for _, arg := range args {
+		var result *models.Result
+		if isResultFile(arg) {
+			parsed, err := dashboard.ParseResultFile(arg)
+			if err != nil {
+				return nil, err
+			}
+			result = parsed
+		} else {
+			if !strings.Contains(arg, ":") {
+				return nil, fmt.Errorf("result not found: %s (use a file path or a reference like vale:latest)", arg)
+			}
+			ref, err := resolveResultRef(arg, "")
+			if err != nil {
+				return nil, err
+			}
+			result = ref.result
+		}
+
+		if previous, ok := seen[result.Checker]; ok {
+			return nil, configError(fmt.Errorf("%s and %s are both %s results; a report shows one result per checker", previous, arg, result.Checker))
+		}
+		seen[result.Checker] = arg
+		results = append(results, result)
+	}
+	return dashboard.FromResults(results), nil
+}
@@ -2,9 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/baseline"
+	"github.com/svx/marvin/cli/internal/app/changes"
+	"github.com/svx/marvin/cli/internal/app/checker"
+	"github.com/svx/marvin/cli/internal/app/dependency"
+	"github.com/svx/marvin/cli/internal/app/gate"
+	"github.com/svx/marvin/cli/internal/app/ignore"
+	"github.com/svx/marvin/cli/internal/app/output"
+	"github.com/svx/marvin/cli/internal/pkg/config"
 )
 
 var (
@@ -14,6 +24,61 @@ var (
 	jsonOutput bool
 	verbose    bool
 	configFile string
+	format     string
+
+	// templateFile is the template of the template format
+	templateFile string
+
+	// Report flags
+	reportFile   string
+	reportFormat string
+
+	// listSuppressed keeps suppressed issues in the results
+	listSuppressed bool
+
+	// Gate flags
+	failOn      string
+	maxErrors   int
+	maxWarnings int
+
+	// gatePolicy decides whether a check fails
+	gatePolicy gate.Policy
+
+	// Baseline flags
+	baselineFile string
+	noBaseline   bool
+
+	// cfg holds the loaded .marvin.yaml configuration
+	cfg = config.Default()
+
+	// knownIssues is the loaded baseline, nil if there is none
+	knownIssues *baseline.Baseline
+
+	// Ignore flags
+	noIgnore     bool
+	useGitignore bool
+
+	// noCache disables the per-file result cache
+	noCache bool
+
+	// Incremental check flags
+	changedSince     string
+	stagedOnly       bool
+	onlyChangedLines bool
+
+	// changedFiles limits the check to files changed in git, nil to check
+	// all files
+	changedFiles *changes.Changes
+
+	// fileFilter excludes ignored files from discovery, nil with --no-ignore
+	fileFilter *ignore.Matcher
+
+	// lspMode is set for the language server, whose stdout belongs to the
+	// protocol
+	lspMode bool
+
+	// logOutput receives verbose logging, on stderr in lspMode
+	logOutput io.Writer = os.Stdout
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -26,15 +91,24 @@ and more.
 
 It provides an interactive TUI for viewing results and can output JSON for
 integration with other tools or CI/CD pipelines.`,
-	Version: "0.1.0",
+	Version:           "0.1.0",
+	PersistentPreRunE: loadConfig,
+
+	// Execute prints errors, so that each error is printed once
+	SilenceErrors: true,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	addCheckerCommands()
+
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		code, silent := exitCode(err)
+		if !silent {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+		os.Exit(code)
 	}
 }
 
@@ -44,5 +118,245 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&noTUI, "no-tui", false, "Disable TUI, output plain text to stdout")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output raw JSON to stdout (implies --no-tui)")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose logging")
-	rootCmd.PersistentFlags().StringVar(&configFile, "config", ".marvin.yaml", "Path to config file")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", config.DefaultConfigFile, "Path to config file")
+	rootCmd.PersistentFlags().StringVar(&format, "format", "", "Output format: "+strings.Join(output.FormatNames(), ", ")+" (implies --no-tui)")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template", "", "Built-in template for the template format, or a template file: "+strings.Join(output.TemplateNames(), ", ")+" (built-in names take precedence, use ./name for a file)")
+	rootCmd.PersistentFlags().StringVar(&baselineFile, "baseline", baseline.DefaultFile, "Baseline file of known issues")
+	rootCmd.PersistentFlags().BoolVar(&noBaseline, "no-baseline", false, "Ignore the baseline file")
+	rootCmd.PersistentFlags().BoolVar(&noIgnore, "no-ignore", false, "Ignore the .marvinignore file")
+	rootCmd.PersistentFlags().BoolVar(&useGitignore, "gitignore", false, "Also skip files matched by .gitignore")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Check all files instead of reusing cached results of unchanged files")
+	rootCmd.PersistentFlags().StringVar(&changedSince, "changed-since", "", "Only check files changed since a git ref")
+	rootCmd.PersistentFlags().BoolVar(&stagedOnly, "staged", false, "Only check files staged in git")
+	rootCmd.PersistentFlags().BoolVar(&onlyChangedLines, "only-changed-lines", false, "Only report issues on changed lines (requires --changed-since or --staged)")
+	rootCmd.PersistentFlags().BoolVar(&listSuppressed, "list-suppressed", false, "List issues hidden by marvin-disable comments")
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", gate.FailOnError, "Lowest severity that fails the check: error, warning, info or none")
+	rootCmd.PersistentFlags().IntVar(&maxErrors, "max-errors", 0, "Number of new errors allowed before the check fails")
+	rootCmd.PersistentFlags().IntVar(&maxWarnings, "max-warnings", 0, "Number of new warnings allowed before the check fails")
+	rootCmd.PersistentFlags().StringVar(&reportFile, "report-file", "", "Also write a report to this file")
+	rootCmd.PersistentFlags().StringVar(&reportFormat, "report-format", "", "Format of the report file: "+strings.Join(output.FormatNames(), ", "))
+}
+
+// loadConfig reads the Marvin config file and applies it to any global flags
+// that were not set explicitly on the command line
+func loadConfig(cmd *cobra.Command, args []string) error {
+	// Flags and arguments have been parsed, so later errors are not about usage
+	cmd.SilenceUsage = true
+
+	lspMode = cmd == lspCmd
+	logOutput = os.Stdout
+	if lspMode {
+		logOutput = os.Stderr
+	}
+
+	// Subcommands may define their own --config flag (e.g. the Vale config),
+	// so only treat the file as required when the root flag was set
+	required := cmd.Root().PersistentFlags().Changed("config")
+
+	loaded, err := config.Load(configFile, required)
+	if err != nil {
+		return configError(err)
+	}
+	cfg = loaded
+
+	if !cmd.Flags().Changed("output-dir") && cfg.OutputDir != "" {
+		outputDir = cfg.OutputDir
+	}
+	if !cmd.Flags().Changed("no-tui") && !cfg.TUI.Enabled {
+		noTUI = true
+	}
+	if !cmd.Flags().Changed("list-suppressed") && cfg.ListSuppressed {
+		listSuppressed = true
+	}
+
+	if err := loadBaseline(cmd); err != nil {
+		return configError(err)
+	}
+	if err := loadIgnore(cmd); err != nil {
+		return configError(err)
+	}
+	if err := loadChanges(cmd); err != nil {
+		return configError(err)
+	}
+	if err := loadGatePolicy(cmd); err != nil {
+		return configError(err)
+	}
+
+	if format != "" {
+		if _, err := output.NewFormatter(format, formatOptions(true)); err != nil {
+			return configError(err)
+		}
+	}
+	if reportFile != "" {
+		if reportFormat == "" {
+			return configError(fmt.Errorf("--report-file requires --report-format"))
+		}
+		if _, err := output.NewFormatter(reportFormat, formatOptions(false)); err != nil {
+			return configError(err)
+		}
+	}
+
+	return nil
+}
+
+// loadBaseline loads the baseline file if it exists. A missing file is only
+// an error if it was set explicitly with --baseline.
+func loadBaseline(cmd *cobra.Command) error {
+	knownIssues = nil
+
+	explicit := cmd.Flags().Changed("baseline")
+	if !explicit && cfg.Baseline != "" {
+		baselineFile = cfg.Baseline
+	}
+
+	// baseline create replaces the baseline, so it must not apply it
+	if noBaseline || cmd == baselineCreateCmd {
+		return nil
+	}
+
+	if _, err := os.Stat(baselineFile); os.IsNotExist(err) && !explicit {
+		return nil
+	}
+
+	loaded, err := baseline.Load(baselineFile)
+	if err != nil {
+		return err
+	}
+	knownIssues = loaded
+
+	if verbose {
+		fmt.Fprintf(logOutput, "Using baseline %s with %d known issues\n", baselineFile, knownIssues.Len())
+	}
+
+	return nil
+}
+
+// loadIgnore sets up the matcher for .marvinignore and, optionally,
+// .gitignore files
+func loadIgnore(cmd *cobra.Command) error {
+	fileFilter = nil
+
+	if !cmd.Flags().Changed("gitignore") && cfg.Ignore.Gitignore {
+		useGitignore = true
+	}
+	if noIgnore {
+		return nil
+	}
+
+	names := []string{ignore.File}
+	if useGitignore {
+		names = append(names, ignore.GitFile)
+	}
+	fileFilter = ignore.New(names...)
+
+	return nil
+}
+
+// loadChanges asks git for the changed files when checking incrementally
+func loadChanges(cmd *cobra.Command) error {
+	changedFiles = nil
+
+	// A baseline of only the changed files would make all other known issues
+	// new
+	if cmd == baselineCreateCmd {
+		for _, name := range []string{"changed-since", "staged", "only-changed-lines"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s cannot be used with baseline create", name)
+			}
+		}
+	}
+
+	if changedSince == "" && !stagedOnly {
+		if onlyChangedLines {
+			return fmt.Errorf("--only-changed-lines requires --changed-since or --staged")
+		}
+		return nil
+	}
+
+	loaded, err := changes.Load(".", changes.Options{Since: changedSince, Staged: stagedOnly})
+	if err != nil {
+		return err
+	}
+	changedFiles = loaded
+
+	if verbose {
+		fmt.Fprintf(logOutput, "Found %d changed files in git\n", changedFiles.Len())
+	}
+
+	return nil
+}
+
+// loadGatePolicy builds the quality gate policy from .marvin.yaml, with
+// flags taking precedence
+func loadGatePolicy(cmd *cobra.Command) error {
+	gates := cfg.Gate
+	gatePolicy = gate.Policy{
+		FailOn:      gates.FailOn,
+		MaxErrors:   gates.MaxErrors,
+		MaxWarnings: gates.MaxWarnings,
+		Rules:       gates.Rules,
+		Paths:       gates.Paths,
+	}
+
+	if cmd.Flags().Changed("fail-on") {
+		gatePolicy.FailOn = failOn
+	}
+	if cmd.Flags().Changed("max-errors") {
+		gatePolicy.MaxErrors = &maxErrors
+	}
+	if cmd.Flags().Changed("max-warnings") {
+		gatePolicy.MaxWarnings = &maxWarnings
+	}
+
+	return gatePolicy.Validate()
+}
+
+// newDetector creates a dependency detector using the configured sources
+func newDetector() *dependency.MultiDetector {
+	deps := cfg.Dependencies
+	return dependency.NewMultiDetectorWith(deps.CheckBrew, deps.CheckNpm, deps.CheckSystem)
+}
+
+// outputFormat returns the format results are written to stdout in, or an
+// empty string if they are shown in the TUI. In GitHub Actions results are
+// written as annotations unless another format is selected.
+func outputFormat() string {
+	switch {
+	case format != "":
+		return strings.ToLower(format)
+	case jsonOutput:
+		return "json"
+	case output.IsGitHubActions():
+		return "github"
+	case noTUI:
+		return "text"
+	}
+	return ""
+}
+
+// formatOptions returns the options of the output formatters. Templates
+// use colors on stdout if it is a terminal and NO_COLOR is not set.
+func formatOptions(stdout bool) output.Options {
+	opts := output.Options{Template: templateFile}
+	if stdout && os.Getenv("NO_COLOR") == "" {
+		if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			opts.Color = true
+		}
+	}
+	return opts
+}
+
+// resolvePath returns the path to scan, falling back to the configured
+// default for the checker, then to the checker's own default and then to docs/
+func resolvePath(def checker.Definition, args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	if path := cfg.Checker(def.Name).Path; path != "" {
+		return path
+	}
+	if def.DefaultPath != "" {
+		return def.DefaultPath
+	}
+	return "docs/"
 }
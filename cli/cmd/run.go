@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/app/cache"
+	"github.com/svx/marvin/cli/internal/app/checker"
+	"github.com/svx/marvin/cli/internal/app/dashboard"
+	"github.com/svx/marvin/cli/internal/app/discovery"
+	"github.com/svx/marvin/cli/internal/app/output"
+	"github.com/svx/marvin/cli/internal/app/suppress"
+	"github.com/svx/marvin/cli/internal/app/tui"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// runCheck discovers the files to check, runs a checker on them and saves
+// the result to the output directory. It returns the result and the path of
+// the saved JSON file.
+func runCheck(ctx context.Context, def checker.Definition, settings checker.Settings, c checker.Checker, opts checker.CheckOptions) (*models.Result, string, error) {
+	result, err := checkResult(ctx, def, settings, c, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return saveResult(result)
+}
+
+// checkResult discovers the files to check and runs a checker on them. The
+// issues are filtered by suppression comments, changed lines and the
+// baseline. Discovery is skipped when opts.Files is already set.
+func checkResult(ctx context.Context, def checker.Definition, settings checker.Settings, c checker.Checker, opts checker.CheckOptions) (*models.Result, error) {
+	if verbose {
+		fmt.Fprintf(logOutput, "Running %s check...\n", c.Name())
+	}
+
+	files := opts.Files
+	if len(files) == 0 {
+		var err error
+		files, err = discovery.Files(opts.Roots(), discovery.Options{
+			Extensions: fileExtensions(def),
+			Ignore:     fileFilter,
+		})
+		if err != nil {
+			return nil, toolError(err)
+		}
+	}
+	if changedFiles != nil {
+		files = changedFiles.Filter(files)
+	}
+	if verbose {
+		fmt.Fprintf(logOutput, "Checking %d files with %s\n", len(files), c.Name())
+	}
+
+	tracker := checker.NewTracker(def.Name, len(files), opts.Progress)
+	tracker.Start()
+	if len(files) == 0 {
+		result := emptyResult(c, opts)
+		tracker.Finish(result)
+		return result, nil
+	}
+	opts.Files = files
+	if opts.Progress != nil {
+		opts.Progress = tracker.Report
+	}
+
+	result, err := checkFiles(ctx, def, settings, c, opts)
+	if err != nil {
+		err = toolError(fmt.Errorf("%s check failed: %w", c.Name(), err))
+		tracker.Fail(err)
+		return nil, err
+	}
+
+	if n := suppress.Apply(result, listSuppressed); n > 0 && verbose {
+		fmt.Fprintf(logOutput, "Suppressed %d issues with marvin-disable comments\n", n)
+	}
+	if onlyChangedLines {
+		n := changedFiles.FilterIssues(result)
+		result.Metadata["unchanged_line_issues"] = n
+	}
+	result.SetFiles(files)
+	if knownIssues != nil {
+		knownIssues.Apply(result)
+	}
+	tracker.Finish(result)
+
+	return result, nil
+}
+
+// saveResult writes a result to the output directory
+func saveResult(result *models.Result) (*models.Result, string, error) {
+	writer := output.NewJSONWriter(outputDir)
+	outputPath, err := writer.Write(result)
+	if err != nil {
+		return nil, "", toolError(fmt.Errorf("failed to save results: %w", err))
+	}
+
+	if verbose {
+		fmt.Fprintf(logOutput, "Results saved to: %s\n", outputPath)
+	}
+
+	return result, outputPath, nil
+}
+
+// checkFiles runs a checker on opts.Files. For checkers whose results can
+// be cached, only files that changed since the last run are checked and the
+// cached issues of the other files are merged into the result.
+func checkFiles(ctx context.Context, def checker.Definition, settings checker.Settings, c checker.Checker, opts checker.CheckOptions) (*models.Result, error) {
+	if noCache || def.Cacheable == nil || !def.Cacheable(settings) {
+		return c.Check(ctx, opts)
+	}
+
+	// 1. Look up each file in the cache
+	configPaths := []string{settings.String("config")}
+	if def.ConfigInputs != nil {
+		configPaths = append(configPaths, def.ConfigInputs(settings)...)
+	}
+	configHash, err := cache.HashPaths(configPaths...)
+	if err != nil {
+		// Without a reliable key, cached issues could be stale
+		return c.Check(ctx, opts)
+	}
+	fileCache := cache.Load(cache.DefaultDir, cache.Key{
+		Checker:     def.Name,
+		ToolVersion: cache.ToolVersion(settings.ToolPath),
+		ConfigHash:  configHash,
+		Settings:    settings.Values,
+	})
+
+	var cached []models.Issue
+	var misses []string
+	for _, file := range opts.Files {
+		if issues, ok := fileCache.Get(file); ok {
+			cached = append(cached, issues...)
+			opts.Report(checker.ProgressEvent{Kind: checker.ProgressFile, File: file, Issues: len(issues)})
+		} else {
+			misses = append(misses, file)
+		}
+	}
+
+	// 2. Check the files that are not cached and cache their issues
+	result := emptyResult(c, opts)
+	if len(misses) > 0 {
+		missOpts := opts
+		missOpts.Files = misses
+
+		if result, err = c.Check(ctx, missOpts); err != nil {
+			return nil, err
+		}
+
+		byFile := make(map[string][]models.Issue)
+		for _, issue := range result.Issues {
+			key := absPath(issue.File)
+			byFile[key] = append(byFile[key], issue)
+		}
+		for _, file := range misses {
+			fileCache.Put(file, byFile[absPath(file)])
+		}
+		if err := fileCache.Save(); err != nil && verbose {
+			fmt.Fprintf(logOutput, "Warning: %v\n", err)
+		}
+	}
+
+	// 3. Merge the cached issues
+	result.Issues = append(result.Issues, cached...)
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		a, b := result.Issues[i], result.Issues[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	result.Recount()
+
+	result.Metadata["file_cache_hits"] = len(opts.Files) - len(misses)
+	result.Metadata["file_cache_misses"] = len(misses)
+	if verbose {
+		fmt.Fprintf(logOutput, "%s cache: %d hits, %d misses\n", def.Name, len(opts.Files)-len(misses), len(misses))
+	}
+
+	return result, nil
+}
+
+// withProgress runs run and shows the progress it reports when results are
+// shown in the TUI. Otherwise, and with verbose logging, run gets no
+// progress function.
+func withProgress(ctx context.Context, title string, run func(ctx context.Context, progress checker.ProgressFunc) error) error {
+	if outputFormat() != "" || verbose {
+		return run(ctx, nil)
+	}
+	return tui.ShowProgress(ctx, title, run)
+}
+
+// absPath returns the absolute form of path for comparing file paths
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// fileExtensions returns the file extensions a checker checks, from
+// .marvin.yaml or the checker definition
+func fileExtensions(def checker.Definition) []string {
+	configured := cfg.Checker(def.Name).Extensions
+	if len(configured) == 0 {
+		return def.FileExtensions()
+	}
+
+	extensions := make([]string, len(configured))
+	for i, ext := range configured {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions[i] = ext
+	}
+	return extensions
+}
+
+// emptyResult returns the result of a check that had no files to check
+func emptyResult(c checker.Checker, opts checker.CheckOptions) *models.Result {
+	return &models.Result{
+		Checker:   c.Name(),
+		Timestamp: time.Now(),
+		Path:      opts.Path,
+		Issues:    []models.Issue{},
+		Metadata:  make(map[string]interface{}),
+	}
+}
+
+// evaluateGate applies the quality gate policy to the results and reports
+// the outcome. Machine-readable output formats keep stdout clean, so the
+// gate report goes to stderr for them.
+func evaluateGate(results []*models.Result) error {
+	report := gatePolicy.Evaluate(results)
+
+	w := os.Stdout
+	if name := outputFormat(); name != "" && name != "text" {
+		w = os.Stderr
+	}
+	fmt.Fprintln(w)
+	report.Write(w)
+
+	if !report.Passed {
+		return issuesFound(fmt.Errorf("quality gate failed"))
+	}
+	return nil
+}
+
+// writeReport writes the results to the --report-file, if set
+func writeReport(results []*models.Result) error {
+	if reportFile == "" {
+		return nil
+	}
+	if err := output.WriteFile(reportFile, reportFormat, formatOptions(false), results); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// displayResult shows a single result in the selected output format or in
+// the TUI depending on the global output flags
+func displayResult(result *models.Result, outputPath string) error {
+	if name := outputFormat(); name != "" {
+		formatter, err := output.NewFormatter(name, formatOptions(true))
+		if err != nil {
+			return err
+		}
+		if err := formatter.Format(result, os.Stdout); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		printSavedPaths(name, []string{outputPath})
+		return nil
+	}
+
+	if err := tui.ShowResults(result); err != nil {
+		return fmt.Errorf("failed to show TUI: %w", err)
+	}
+	printSavedPaths("", []string{outputPath})
+
+	return nil
+}
+
+// displayResults shows the results of several checkers in the selected
+// output format or in the dashboard TUI depending on the global output flags
+func displayResults(results []*models.Result, outputPaths []string) error {
+	if name := outputFormat(); name != "" {
+		formatter, err := output.NewFormatter(name, formatOptions(true))
+		if err != nil {
+			return err
+		}
+		if err := output.FormatAll(formatter, results, os.Stdout); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		printSavedPaths(name, outputPaths)
+		return nil
+	}
+
+	if len(results) > 0 {
+		if err := tui.ShowDashboard(dashboard.FromResults(results)); err != nil {
+			return fmt.Errorf("failed to show TUI: %w", err)
+		}
+	}
+	printSavedPaths("", outputPaths)
+
+	return nil
+}
+
+// printSavedPaths lists the saved JSON files after text or TUI output.
+// Machine-readable formats are kept clean so they can be piped.
+func printSavedPaths(format string, outputPaths []string) {
+	if format != "" && format != "text" {
+		return
+	}
+	fmt.Println()
+	for _, outputPath := range outputPaths {
+		fmt.Printf("Results saved to: %s\n", outputPath)
+	}
+	if reportFile != "" {
+		fmt.Printf("Report saved to: %s\n", reportFile)
+	}
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/checker"
+	"github.com/svx/marvin/cli/internal/app/server"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	serveAddr        string
+	serveWorkers     int
+	serveQueueSize   int
+	serveAllowOrigin string
+	serveAllowRemote bool
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve results and check runs over an HTTP API",
+	Long: `Start an HTTP server with a JSON API over the saved results and check runs.
+
+Endpoints:
+
+  GET  /api/results          List saved results, newest first
+                             (query: checker, limit, offset)
+  GET  /api/results/{id}     Get a saved result
+  GET  /api/dashboard        Aggregated results of all checkers
+  GET  /api/checkers         List registered checkers
+  POST /api/checks           Queue a check: {"checker": "vale", "paths": ["docs"]}
+  GET  /api/checks           List check jobs
+  GET  /api/checks/{id}      Get the status of a check job
+  GET  /api/checks/{id}/events
+                             Stream the progress of a check job as
+                             Server-Sent Events
+
+Checks run in the background with at most --workers at a time. A queued
+check returns a job that can be polled until its status is "succeeded" or
+"failed"; a succeeded job links to its saved result. The events endpoint
+streams "started", "file", "finished" and "failed" progress events and ends
+with a "done" event holding the job. Paths must be inside the directory the
+server runs in.
+
+The API has no authentication and lets clients run checks, so the server
+only listens on loopback addresses unless --allow-remote is set. Requests
+must be for a loopback host or the --addr host, browser requests must come
+from the server or --allow-origin, and checks must be queued with a JSON
+body.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+	Example: `  # Serve on the default address
+  marvin serve
+
+  # Serve on another port and allow requests from the web app
+  marvin serve --addr localhost:9090 --allow-origin http://localhost:3000
+
+  # Serve on all interfaces, for example inside a container
+  marvin serve --addr :8080 --allow-remote
+
+  # Queue a Vale check and poll its status
+  curl -X POST localhost:8080/api/checks -H 'Content-Type: application/json' -d '{"checker": "vale"}'
+  curl localhost:8080/api/checks/<id>
+
+  # Follow the progress of a check
+  curl -N localhost:8080/api/checks/<id>/events`,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	// Command-specific flags
+	serveCmd.Flags().StringVar(&serveAddr, "addr", server.DefaultAddr, "Address to listen on")
+	serveCmd.Flags().IntVar(&serveWorkers, "workers", server.DefaultWorkers, "Number of checks that run at the same time")
+	serveCmd.Flags().IntVar(&serveQueueSize, "queue-size", server.DefaultQueueSize, "Number of checks that can wait to run")
+	serveCmd.Flags().StringVar(&serveAllowOrigin, "allow-origin", "", "Origin allowed to call the API from a browser (CORS)")
+	serveCmd.Flags().BoolVar(&serveAllowRemote, "allow-remote", false, "Allow listening on addresses other than loopback")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	// 1. Refuse to expose the unauthenticated API unless asked to
+	if !isLoopbackAddr(serveAddr) {
+		if !serveAllowRemote {
+			return configError(fmt.Errorf("%s is not a loopback address; the API has no authentication, pass --allow-remote to serve it anyway", serveAddr))
+		}
+		fmt.Fprintf(os.Stderr, "Warning: serving the API without authentication on %s\n", serveAddr)
+	}
+
+	// 2. Start the job queue
+	jobs := server.NewQueue(func(ctx context.Context, req server.CheckRequest, progress checker.ProgressFunc) (*models.Result, string, error) {
+		return runServerCheck(ctx, cmd, req, progress)
+	}, serveWorkers, serveQueueSize)
+	jobs.Start(ctx)
+
+	// 3. Serve the API until interrupted
+	httpServer := &http.Server{
+		Addr: serveAddr,
+		Handler: server.New(server.Options{
+			Addr:        serveAddr,
+			OutputDir:   outputDir,
+			Jobs:        jobs,
+			Enabled:     func(name string) bool { return cfg.Checker(name).IsEnabled() },
+			AllowOrigin: serveAllowOrigin,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+	}()
+	fmt.Printf("Serving the Marvin API on http://%s\n", serveAddr)
+
+	select {
+	case err := <-errs:
+		return configError(fmt.Errorf("failed to serve: %w", err))
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+	return nil
+}
+
+// isLoopbackAddr reports whether a listen address only accepts connections
+// from the local machine. An empty host listens on all interfaces.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// runServerCheck runs a check requested through the API and saves its
+// result like the checker command would
+func runServerCheck(ctx context.Context, cmd *cobra.Command, req server.CheckRequest, progress checker.ProgressFunc) (*models.Result, string, error) {
+	def, ok := checker.Lookup(req.Checker)
+	if !ok {
+		return nil, "", fmt.Errorf("unknown checker: %s", req.Checker)
+	}
+
+	paths := req.Paths
+	if len(paths) == 0 {
+		paths = []string{resolvePath(def, nil)}
+	}
+	if err := checkPathsExist(paths); err != nil {
+		return nil, "", err
+	}
+
+	c, settings, err := newChecker(cmd, def, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return runCheck(ctx, def, settings, c, checker.CheckOptions{
+		Path:       strings.Join(paths, " "),
+		Paths:      paths,
+		ConfigFile: settings.String("config"),
+		Progress:   progress,
+	})
+}
@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/svx/marvin/cli/internal/app/discovery"
+	"github.com/svx/marvin/cli/internal/app/output"
+	"github.com/svx/marvin/cli/internal/app/tui"
+	"github.com/svx/marvin/cli/internal/app/watch"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+var (
+	watchCheckers []string
+	watchDebounce time.Duration
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch [paths...]",
+	Short: "Re-run checkers when files change",
+	Long: `Watch the documentation tree and re-run the enabled checkers whenever files
+change.
+
+All files are checked once at the start. After that, only the changed files
+are checked again, and the results are updated in place in a live TUI that
+lists the issues per file with a running total. With --no-tui or --format
+the results are printed after every run instead.
+
+Changes are collected until no file changed for the --debounce interval, so
+that saving several files at once results in a single run. Ignored files
+and hidden directories are not watched. Results are not saved to the output
+directory and the quality gate is not evaluated.
+
+Checkers that look at more than one file, such as links, only re-check the
+links in the changed files.`,
+	RunE: runWatch,
+	Example: `  # Watch the default paths of all enabled checkers
+  marvin watch
+
+  # Watch a directory with Vale only
+  marvin watch ./docs --checkers vale
+
+  # Print plain text results after every run
+  marvin watch --no-tui`,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	// Command-specific flags
+	watchCmd.Flags().StringSliceVar(&watchCheckers, "checkers", nil,
+		"Comma-separated list of checkers to run (default: all enabled checkers)")
+	watchCmd.Flags().DurationVar(&watchDebounce, "debounce", watch.DefaultDebounce,
+		"How long to wait for more changes before checking")
+}
+
+// watchState holds the issues of each file a checker has checked
+type watchState struct {
+	// files maps absolute file paths to the issues of the file
+	files map[string]*watchedFile
+
+	// result is the combined result of all runs
+	result *models.Result
+}
+
+// watchedFile is a checked file and its issues
+type watchedFile struct {
+	path   string
+	issues []models.Issue
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	// 1. Create the checkers
+	outcomes, err := planChecks(cmd, args, watchCheckers)
+	if err != nil {
+		return err
+	}
+
+	roots := []string{}
+	seenRoots := make(map[string]bool)
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", outcome.name, outcome.err)
+			continue
+		}
+		for _, root := range outcome.plan.opts.Roots() {
+			if !seenRoots[filepath.Clean(root)] {
+				seenRoots[filepath.Clean(root)] = true
+				roots = append(roots, root)
+			}
+		}
+	}
+	if len(roots) == 0 {
+		return toolError(fmt.Errorf("no checkers to run"))
+	}
+
+	// 2. Start watching before the first run, so that no change is missed
+	watcher, err := watch.New(roots, watchDebounce, watchIgnored)
+	if err != nil {
+		return toolError(err)
+	}
+	defer watcher.Close()
+
+	// 3. Check and re-check files until the user quits
+	if outputFormat() != "" {
+		return watchLoop(ctx, outcomes, watcher, printWatchUpdate)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates := make(chan tui.LiveUpdate)
+	go watchLoop(ctx, outcomes, watcher, func(update tui.LiveUpdate) {
+		select {
+		case updates <- update:
+		case <-ctx.Done():
+		}
+	})
+
+	if err := tui.ShowLiveResults("Watching "+strings.Join(roots, ", "), updates); err != nil {
+		return fmt.Errorf("failed to show TUI: %w", err)
+	}
+	return nil
+}
+
+// watchIgnored reports whether the ignore files exclude a path from being
+// watched. The ignore files of the path's parent directories are loaded
+// first, since the watcher adds directories before any files are
+// discovered.
+func watchIgnored(path string, isDir bool) bool {
+	if fileFilter == nil {
+		return false
+	}
+	if err := fileFilter.LoadParents(filepath.Dir(path)); err != nil {
+		return false
+	}
+	return fileFilter.Ignored(path, isDir)
+}
+
+// watchLoop checks all files once and then the changed files of every batch
+// reported by the watcher, until ctx is done. The state after each step is
+// passed to show.
+func watchLoop(ctx context.Context, outcomes []*checkOutcome, watcher *watch.Watcher, show func(tui.LiveUpdate)) error {
+	// 1. Check all files
+	states := make(map[string]*watchState)
+	for _, outcome := range outcomes {
+		if outcome.plan != nil {
+			states[outcome.name] = &watchState{files: make(map[string]*watchedFile)}
+		}
+	}
+
+	runPlans(outcomes, func(plan *checkPlan) (*models.Result, string, error) {
+		return states[plan.def.Name].check(ctx, plan, nil)
+	})
+	show(watchUpdate(outcomes, nil, nil))
+
+	// 2. Re-check changed files
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err := <-watcher.Errors():
+			update := watchUpdate(outcomes, nil, nil)
+			update.Err = err
+			show(update)
+
+		case batch := <-watcher.Changes():
+			show(watchUpdate(outcomes, batch, nil))
+			runPlans(outcomes, func(plan *checkPlan) (*models.Result, string, error) {
+				return states[plan.def.Name].check(ctx, plan, batch)
+			})
+			show(watchUpdate(outcomes, nil, batch))
+		}
+	}
+}
+
+// check runs a checker on the changed files that it checks and merges the
+// issues into the state. With no changed files, all files are checked.
+func (s *watchState) check(ctx context.Context, plan *checkPlan, changed []string) (*models.Result, string, error) {
+	opts := plan.opts
+	if changed != nil {
+		files := s.filter(plan, changed)
+		if len(files) == 0 {
+			// Removed files may still have to be dropped from the result
+			if s.result != nil {
+				s.result = s.combine(s.result)
+			}
+			return s.result, "", nil
+		}
+		opts.Files = files
+	}
+
+	result, err := checkResult(ctx, plan.def, plan.settings, plan.checker, opts)
+	if err != nil {
+		return s.result, "", err
+	}
+
+	// Replace the issues of the checked files
+	checked := opts.Files
+	if changed == nil {
+		checked = append(append([]string{}, result.CleanFiles...), issueFiles(result)...)
+	}
+	for _, file := range checked {
+		s.files[absPath(file)] = &watchedFile{path: file}
+	}
+	for _, issue := range result.Issues {
+		file, ok := s.files[absPath(issue.File)]
+		if !ok {
+			file = &watchedFile{path: issue.File}
+			s.files[absPath(issue.File)] = file
+		}
+		file.issues = append(file.issues, issue)
+	}
+
+	s.result = s.combine(result)
+	return s.result, "", nil
+}
+
+// filter returns the changed files that a checker checks and forgets those
+// that were removed
+func (s *watchState) filter(plan *checkPlan, changed []string) []string {
+	extensions := fileExtensions(plan.def)
+
+	files := []string{}
+	for _, path := range changed {
+		if !underRoots(path, plan.opts.Roots()) || !discovery.HasExtension(path, extensions) {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			delete(s.files, absPath(path))
+			continue
+		}
+		if fileFilter != nil && fileFilter.Ignored(path, false) {
+			continue
+		}
+		files = append(files, path)
+	}
+
+	if changedFiles != nil {
+		files = changedFiles.Filter(files)
+	}
+	return files
+}
+
+// combine returns a result with the issues of all files, taking the other
+// fields from the latest result
+func (s *watchState) combine(latest *models.Result) *models.Result {
+	combined := *latest
+	combined.Issues = []models.Issue{}
+	combined.Suppressed = nil
+
+	files := make([]string, 0, len(s.files))
+	for _, file := range s.files {
+		files = append(files, file.path)
+		combined.Issues = append(combined.Issues, file.issues...)
+	}
+	sort.Strings(files)
+	sort.SliceStable(combined.Issues, func(i, j int) bool {
+		a, b := combined.Issues[i], combined.Issues[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	combined.SetFiles(files)
+	return &combined
+}
+
+// issueFiles returns the files that have issues in result
+func issueFiles(result *models.Result) []string {
+	files := []string{}
+	seen := make(map[string]bool)
+	for _, issue := range result.Issues {
+		if !seen[issue.File] {
+			seen[issue.File] = true
+			files = append(files, issue.File)
+		}
+	}
+	return files
+}
+
+// underRoots reports whether path is one of roots or inside one of them
+func underRoots(path string, roots []string) bool {
+	path = absPath(path)
+	for _, root := range roots {
+		root = absPath(root)
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchUpdate collects the current results of all checkers. running lists
+// the files of a run that has started, checked those of a finished run.
+func watchUpdate(outcomes []*checkOutcome, running, checked []string) tui.LiveUpdate {
+	update := tui.LiveUpdate{
+		Running: running,
+		Changed: checked,
+		Time:    time.Now(),
+	}
+
+	var errs []error
+	for _, outcome := range outcomes {
+		if outcome.result != nil {
+			update.Results = append(update.Results, outcome.result)
+		}
+		if outcome.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", outcome.name, outcome.err))
+		}
+	}
+	update.Err = errors.Join(errs...)
+
+	return update
+}
+
+// printWatchUpdate prints the results after each run in the selected
+// output format
+func printWatchUpdate(update tui.LiveUpdate) {
+	if len(update.Running) > 0 {
+		return
+	}
+
+	if update.Err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", update.Err)
+	}
+
+	if update.Changed != nil {
+		fmt.Fprintf(os.Stderr, "\n[%s] Checked %d changed files\n", update.Time.Format("15:04:05"), len(update.Changed))
+	} else {
+		fmt.Fprintf(os.Stderr, "[%s] Watching for changes (press Ctrl+C to stop)\n", update.Time.Format("15:04:05"))
+	}
+
+	formatter, err := output.NewFormatter(outputFormat(), formatOptions(true))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return
+	}
+	if err := output.FormatAll(formatter, update.Results, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to format output: %v\n", err)
+	}
+}
@@ -0,0 +1,194 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// DefaultFile is the baseline file Marvin uses when none is configured.
+// It lives next to .marvin.yaml so it can be committed with the docs.
+const DefaultFile = ".marvin-baseline.json"
+
+// Version is the version of the baseline file format. Version 1 files have
+// no fingerprints and are matched by checker, file, rule and context.
+const Version = 2
+
+// Baseline is a snapshot of known issues. Issues are matched by their
+// fingerprint rather than by line number, so the baseline keeps matching
+// when lines move.
+type Baseline struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Entries []Entry   `json:"entries"`
+}
+
+// Entry is a known issue. Checker, file, rule and context are kept for
+// readability and for matching issues without a fingerprint. Count is the
+// number of identical issues without a fingerprint, so that a repeated issue
+// only matches as often as it was recorded.
+type Entry struct {
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Checker     string `json:"checker"`
+	File        string `json:"file"`
+	Rule        string `json:"rule"`
+	Context     string `json:"context"`
+	Count       int    `json:"count,omitempty"`
+}
+
+// key identifies an issue of a checker independent of its position in the
+// file
+type key struct {
+	checker string
+	models.IssueKey
+}
+
+// New creates a baseline from the issues in results
+func New(results []*models.Result) *Baseline {
+	b := &Baseline{
+		Version: Version,
+		Created: time.Now(),
+		Entries: []Entry{},
+	}
+
+	counts := make(map[key]int)
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			k := key{result.Checker, issue.Key()}
+			if issue.Fingerprint == "" {
+				counts[k]++
+				continue
+			}
+			b.Entries = append(b.Entries, Entry{
+				Fingerprint: issue.Fingerprint,
+				Checker:     k.checker,
+				File:        k.File,
+				Rule:        k.Rule,
+				Context:     k.Context,
+			})
+		}
+	}
+	for k, count := range counts {
+		b.Entries = append(b.Entries, Entry{
+			Checker: k.checker,
+			File:    k.File,
+			Rule:    k.Rule,
+			Context: k.Context,
+			Count:   count,
+		})
+	}
+
+	// Keep the file stable so baseline updates produce small diffs
+	sort.Slice(b.Entries, func(i, j int) bool {
+		a, c := b.Entries[i], b.Entries[j]
+		if a.Checker != c.Checker {
+			return a.Checker < c.Checker
+		}
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		if a.Rule != c.Rule {
+			return a.Rule < c.Rule
+		}
+		if a.Context != c.Context {
+			return a.Context < c.Context
+		}
+		return a.Fingerprint < c.Fingerprint
+	})
+
+	return b
+}
+
+// Load reads a baseline file
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s: %w", path, err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if b.Version > Version {
+		return nil, fmt.Errorf("baseline %s has unsupported version %d", path, b.Version)
+	}
+
+	return &b, nil
+}
+
+// Save writes the baseline to path
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create baseline directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Len returns the number of known issues in the baseline
+func (b *Baseline) Len() int {
+	total := 0
+	for _, entry := range b.Entries {
+		if entry.Fingerprint != "" {
+			total++
+		} else {
+			total += entry.Count
+		}
+	}
+	return total
+}
+
+// Apply marks the issues in result that are in the baseline as baselined
+// and recounts the summary. It returns the number of baselined issues.
+func (b *Baseline) Apply(result *models.Result) int {
+	fingerprints := make(map[string]bool)
+	remaining := make(map[key]int)
+	for _, entry := range b.Entries {
+		if entry.Checker != result.Checker {
+			continue
+		}
+		if entry.Fingerprint != "" {
+			fingerprints[entry.Fingerprint] = true
+			continue
+		}
+		remaining[key{entry.Checker, models.IssueKey{File: entry.File, Rule: entry.Rule, Context: entry.Context}}] += entry.Count
+	}
+
+	matched := 0
+	for i := range result.Issues {
+		issue := &result.Issues[i]
+		if issue.Fingerprint != "" && fingerprints[issue.Fingerprint] {
+			issue.Baselined = true
+			matched++
+			continue
+		}
+
+		// Fall back to position-independent keys for entries and issues
+		// without fingerprints
+		k := key{result.Checker, issue.Key()}
+		if remaining[k] > 0 {
+			remaining[k]--
+			issue.Baselined = true
+			matched++
+		}
+	}
+
+	result.Recount()
+	return matched
+}
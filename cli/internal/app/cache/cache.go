@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// DefaultDir is the directory Marvin keeps its caches in
+const DefaultDir = ".marvin/cache"
+
+// version is the on-disk format version; caches of other versions are
+// discarded
+const version = 1
+
+// Key describes the checker setup that produced the cached issues. Cached
+// issues are only reused when the whole key matches.
+type Key struct {
+	Checker     string
+	ToolVersion string
+
+	// ConfigHash is the hash of the tool config file
+	ConfigHash string
+
+	// Settings are the resolved checker settings, such as flag values
+	Settings map[string]string
+}
+
+// hash returns a stable hash of the key
+func (k Key) hash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", k.Checker, k.ToolVersion, k.ConfigHash)
+
+	names := make([]string, 0, len(k.Settings))
+	for name := range k.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "\x00%s=%s", name, k.Settings[name])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// entry holds the issues of one file
+type entry struct {
+	// Hash covers the key and the file content
+	Hash   string         `json:"hash"`
+	Issues []models.Issue `json:"issues"`
+}
+
+// document is the on-disk format of the cache
+type document struct {
+	Version int              `json:"version"`
+	Entries map[string]entry `json:"entries"`
+}
+
+// Cache stores the issues of each file for one checker, so that unchanged
+// files do not need to be checked again. It is not safe for concurrent use;
+// each checker has its own cache.
+type Cache struct {
+	path    string
+	key     string
+	entries map[string]entry
+
+	// hashes remembers the entry hash computed for each file by Get
+	hashes map[string]string
+}
+
+// Load reads the cache of a checker from dir. A missing or unreadable cache
+// file results in an empty cache.
+func Load(dir string, key Key) *Cache {
+	c := &Cache{
+		path:    filepath.Join(dir, "files-"+key.Checker+".json"),
+		key:     key.hash(),
+		entries: make(map[string]entry),
+		hashes:  make(map[string]string),
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil || doc.Version != version || doc.Entries == nil {
+		return c
+	}
+	c.entries = doc.Entries
+
+	return c
+}
+
+// Get returns the cached issues of a file if neither the file nor the key
+// changed since they were stored
+func (c *Cache) Get(file string) ([]models.Issue, bool) {
+	file = filepath.Clean(file)
+
+	hash, err := c.entryHash(file)
+	if err != nil {
+		return nil, false
+	}
+	c.hashes[file] = hash
+
+	cached, ok := c.entries[file]
+	if !ok || cached.Hash != hash {
+		return nil, false
+	}
+	return cached.Issues, true
+}
+
+// Put stores the issues of a file
+func (c *Cache) Put(file string, issues []models.Issue) {
+	file = filepath.Clean(file)
+
+	hash, ok := c.hashes[file]
+	if !ok {
+		var err error
+		if hash, err = c.entryHash(file); err != nil {
+			return
+		}
+	}
+
+	if issues == nil {
+		issues = []models.Issue{}
+	}
+	c.entries[file] = entry{Hash: hash, Issues: issues}
+}
+
+// Save writes the cache to disk. Entries of files that no longer exist are
+// dropped.
+func (c *Cache) Save() error {
+	for file := range c.entries {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			delete(c.entries, file)
+		}
+	}
+
+	data, err := json.Marshal(document{Version: version, Entries: c.entries})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	return nil
+}
+
+// entryHash hashes the key together with the content of file
+func (c *Cache) entryHash(file string) (string, error) {
+	content, err := HashFile(file)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(c.key + "\x00" + content))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HashFile returns the SHA-256 hash of a file's content, or an empty string
+// if path is empty
+func HashFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HashPaths returns the SHA-256 hash of the content of files and of all
+// files below directories, together with their names. Empty and missing
+// paths are skipped.
+func HashPaths(paths ...string) (string, error) {
+	h := sha256.New()
+	for _, root := range paths {
+		if root == "" {
+			continue
+		}
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256(data)
+			fmt.Fprintf(h, "%s\x00%x\x00", filepath.ToSlash(path), sum)
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", root, err)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ToolVersion returns the version reported by `tool --version`. If the tool
+// does not report one, the size and modification time of its executable are
+// used instead, so that upgrading the tool still invalidates the cache.
+func ToolVersion(toolPath string) string {
+	if toolPath == "" {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, toolPath, "--version")
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err == nil {
+		if version := strings.TrimSpace(stdout.String()); version != "" {
+			return version
+		}
+	}
+
+	info, err := os.Stat(toolPath)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", info.Size(), info.ModTime().UnixNano())
+}
@@ -0,0 +1,202 @@
+package changes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// Options select the changes to check
+type Options struct {
+	// Since is the git ref to compare with. Changes are taken relative to
+	// the merge base of Since and HEAD, so commits on Since that are not in
+	// HEAD do not count. Uncommitted and untracked files count as changed.
+	Since string
+
+	// Staged limits the changes to those in the git index
+	Staged bool
+}
+
+// Changes holds the files and lines changed in a git repository
+type Changes struct {
+	// files maps absolute file paths to their changed lines. A nil line set
+	// means that the whole file is new.
+	files map[string]lineSet
+}
+
+// lineSet is a set of 1-based line numbers
+type lineSet map[int]bool
+
+var hunkPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// Load asks git for the changes in the repository that contains dir
+func Load(dir string, opts Options) (*Changes, error) {
+	// The root is derived from dir rather than taken from git, so that file
+	// paths compare equal even when dir is reached through a symlink
+	cdup, err := git(dir, "rev-parse", "--show-cdup")
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository: %w", err)
+	}
+	root := filepath.Join(absPath(dir), strings.TrimSpace(cdup))
+
+	// 1. Build the diff arguments
+	args := []string{"-c", "core.quotePath=false", "diff", "-U0", "--no-color", "--no-ext-diff", "--diff-filter=ACMR", "--src-prefix=a/", "--dst-prefix=b/"}
+	if opts.Staged {
+		args = append(args, "--cached")
+	}
+	if opts.Since != "" {
+		base, err := git(root, "merge-base", opts.Since, "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find merge base with %s: %w", opts.Since, err)
+		}
+		args = append(args, strings.TrimSpace(base))
+	}
+
+	// 2. Parse changed lines from the diff hunks
+	patch, err := git(root, append(args, "--")...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git diff: %w", err)
+	}
+	c := &Changes{files: parsePatch(root, patch)}
+
+	// 3. Untracked files are entirely new
+	if !opts.Staged {
+		untracked, err := git(root, "ls-files", "-z", "--others", "--exclude-standard")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list untracked files: %w", err)
+		}
+		for _, name := range strings.Split(untracked, "\x00") {
+			if name != "" {
+				c.files[filepath.Join(root, filepath.FromSlash(name))] = nil
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// Len returns the number of changed files
+func (c *Changes) Len() int {
+	return len(c.files)
+}
+
+// Contains reports whether a file was changed
+func (c *Changes) Contains(path string) bool {
+	_, ok := c.files[absPath(path)]
+	return ok
+}
+
+// Filter returns the files that were changed
+func (c *Changes) Filter(files []string) []string {
+	changed := []string{}
+	for _, file := range files {
+		if c.Contains(file) {
+			changed = append(changed, file)
+		}
+	}
+	return changed
+}
+
+// LineChanged reports whether a line of a file was added or modified.
+// Issues without a line are treated as changed when their file is.
+func (c *Changes) LineChanged(path string, line int) bool {
+	lines, ok := c.files[absPath(path)]
+	if !ok {
+		return false
+	}
+	return lines == nil || line < 1 || lines[line]
+}
+
+// FilterIssues removes the issues of result that are not on changed lines
+// and returns the number of removed issues
+func (c *Changes) FilterIssues(result *models.Result) int {
+	kept := make([]models.Issue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		if c.LineChanged(issue.File, issue.Line) {
+			kept = append(kept, issue)
+		}
+	}
+
+	removed := len(result.Issues) - len(kept)
+	if removed > 0 {
+		result.Issues = kept
+		result.Recount()
+	}
+	return removed
+}
+
+// parsePatch collects the added and modified lines per file from a
+// zero-context unified diff
+func parsePatch(root, patch string) map[string]lineSet {
+	files := make(map[string]lineSet)
+	var current lineSet
+
+	scanner := bufio.NewScanner(strings.NewReader(patch))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			// git appends a tab to names that contain spaces
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "+++ "), "\t")
+			if name == "/dev/null" {
+				current = nil
+				continue
+			}
+			name = strings.TrimPrefix(name, "b/")
+			current = make(lineSet)
+			files[filepath.Join(root, filepath.FromSlash(name))] = current
+
+		case strings.HasPrefix(line, "@@ "):
+			match := hunkPattern.FindStringSubmatch(line)
+			if match == nil || current == nil {
+				continue
+			}
+			start, _ := strconv.Atoi(match[1])
+			count := 1
+			if match[2] != "" {
+				count, _ = strconv.Atoi(match[2])
+			}
+			for i := 0; i < count; i++ {
+				current[start+i] = true
+			}
+		}
+	}
+
+	return files
+}
+
+// absPath returns the absolute, cleaned form of path
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
+
+// git runs a git command in dir and returns its output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
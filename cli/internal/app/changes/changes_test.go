@@ -0,0 +1,218 @@
+package changes
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// newRepo creates a git repository in a temporary directory, isolated from
+// the user's git config
+func newRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Marvin")
+	t.Setenv("GIT_AUTHOR_EMAIL", "marvin@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Marvin")
+	t.Setenv("GIT_COMMITTER_EMAIL", "marvin@example.com")
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	return dir
+}
+
+// runGit runs a git command in dir and fails the test on errors
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if _, err := git(dir, args...); err != nil {
+		t.Fatalf("git %s: %v", strings.Join(args, " "), err)
+	}
+}
+
+// writeLines writes a file with one line per element and returns its path
+func writeLines(t *testing.T, dir, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// commitAll commits all files in dir
+func commitAll(t *testing.T, dir, message string) {
+	t.Helper()
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", message)
+}
+
+func TestLoadSince(t *testing.T) {
+	dir := newRepo(t)
+	edited := writeLines(t, dir, "docs/edited.md", "one", "two", "three", "four", "five")
+	branched := writeLines(t, dir, "docs/branched.md", "one", "two", "three")
+	upstream := writeLines(t, dir, "docs/upstream.md", "one", "two", "three")
+	unchanged := writeLines(t, dir, "docs/unchanged.md", "one")
+	commitAll(t, dir, "initial")
+
+	// A commit on main after the feature branch was created does not count
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	runGit(t, dir, "checkout", "-q", "main")
+	writeLines(t, dir, "docs/upstream.md", "one", "TWO", "three")
+	commitAll(t, dir, "upstream change")
+
+	// Committed, unstaged, staged and untracked changes on the branch count
+	runGit(t, dir, "checkout", "-q", "feature")
+	writeLines(t, dir, "docs/branched.md", "one", "TWO", "three")
+	commitAll(t, dir, "branch change")
+	writeLines(t, dir, "docs/edited.md", "one", "two", "three", "FOUR", "five")
+	staged := writeLines(t, dir, "docs/staged.md", "one", "two")
+	runGit(t, dir, "add", "docs/staged.md")
+	untracked := writeLines(t, dir, "docs/untracked.md", "one")
+
+	c, err := Load(dir, Options{Since: "main"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if c.Len() != 4 {
+		t.Errorf("Len = %d, want 4", c.Len())
+	}
+	got := c.Filter([]string{edited, branched, upstream, unchanged, staged, untracked})
+	want := []string{edited, branched, staged, untracked}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+
+	lines := []struct {
+		path    string
+		line    int
+		changed bool
+	}{
+		{edited, 4, true},
+		{edited, 3, false},
+		{branched, 2, true},
+		{branched, 1, false},
+		{upstream, 2, false},
+		{staged, 1, true},
+		{staged, 3, false},
+		{untracked, 10, true},
+		{edited, 0, true},
+		{unchanged, 0, false},
+	}
+	for _, tt := range lines {
+		if got := c.LineChanged(tt.path, tt.line); got != tt.changed {
+			t.Errorf("LineChanged(%s, %d) = %v, want %v", filepath.Base(tt.path), tt.line, got, tt.changed)
+		}
+	}
+}
+
+func TestLoadStaged(t *testing.T) {
+	dir := newRepo(t)
+	staged := writeLines(t, dir, "staged.md", "one", "two", "three")
+	unstaged := writeLines(t, dir, "unstaged.md", "one", "two", "three")
+	commitAll(t, dir, "initial")
+
+	writeLines(t, dir, "staged.md", "one", "two", "THREE")
+	runGit(t, dir, "add", "staged.md")
+	writeLines(t, dir, "unstaged.md", "ONE", "two", "three")
+	untracked := writeLines(t, dir, "untracked.md", "one")
+
+	c, err := Load(dir, Options{Staged: true})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	got := c.Filter([]string{staged, unstaged, untracked})
+	if want := []string{staged}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+	if !c.LineChanged(staged, 3) || c.LineChanged(staged, 1) {
+		t.Errorf("LineChanged: want only line 3 of staged.md changed")
+	}
+	if c.LineChanged(unstaged, 1) {
+		t.Errorf("LineChanged: unstaged change reported as staged")
+	}
+}
+
+func TestLoadStagedSince(t *testing.T) {
+	dir := newRepo(t)
+	writeLines(t, dir, "base.md", "one")
+	commitAll(t, dir, "initial")
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+
+	committed := writeLines(t, dir, "committed.md", "one")
+	commitAll(t, dir, "feature commit")
+	staged := writeLines(t, dir, "staged.md", "one")
+	runGit(t, dir, "add", "staged.md")
+	writeLines(t, dir, "committed.md", "one", "two")
+
+	c, err := Load(dir, Options{Since: "main", Staged: true})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	got := c.Filter([]string{committed, staged})
+	if want := []string{committed, staged}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+	if c.LineChanged(committed, 2) {
+		t.Errorf("LineChanged: unstaged line reported as changed")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	if _, err := Load(dir, Options{Since: "main"}); err == nil {
+		t.Error("Load outside a repository: want an error")
+	}
+
+	repo := newRepo(t)
+	writeLines(t, repo, "a.md", "one")
+	commitAll(t, repo, "initial")
+	if _, err := Load(repo, Options{Since: "no-such-ref"}); err == nil {
+		t.Error("Load with an unknown ref: want an error")
+	}
+}
+
+func TestFilterIssues(t *testing.T) {
+	dir := newRepo(t)
+	path := writeLines(t, dir, "doc.md", "one", "two", "three")
+	commitAll(t, dir, "initial")
+	writeLines(t, dir, "doc.md", "one", "TWO", "three")
+
+	c, err := Load(dir, Options{Since: "HEAD"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	result := &models.Result{Issues: []models.Issue{
+		{File: path, Line: 1, Severity: "error"},
+		{File: path, Line: 2, Severity: "warning"},
+		{File: path, Line: 3, Severity: "error"},
+	}}
+	result.Recount()
+
+	if removed := c.FilterIssues(result); removed != 2 {
+		t.Errorf("FilterIssues removed %d issues, want 2", removed)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Line != 2 {
+		t.Errorf("Issues = %+v, want the issue on line 2", result.Issues)
+	}
+	if result.Summary.TotalIssues != 1 || result.Summary.ErrorCount != 0 || result.Summary.WarningCount != 1 {
+		t.Errorf("Summary = %+v, want one warning", result.Summary)
+	}
+}
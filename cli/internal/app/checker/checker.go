@@ -2,6 +2,7 @@ package checker
 
 import (
 	"context"
+	"runtime"
 
 	"github.com/svx/marvin/cli/internal/pkg/models"
 )
@@ -18,10 +19,67 @@ type Checker interface {
 	Validate() error
 }
 
-// CheckOptions contains options for running a check
+// CheckOptions contains options for running a check.
+// It is passed to checker plugins as JSON, so fields carry JSON tags.
 type CheckOptions struct {
-	Path         string
-	ConfigFile   string
-	OutputFormat string
-	ExtraArgs    []string
+	Path  string   `json:"path"`
+	Paths []string `json:"paths,omitempty"`
+
+	// Files is the resolved list of files to check after applying ignore
+	// files. When set, checkers receive these files instead of Paths.
+	Files []string `json:"files,omitempty"`
+
+	ConfigFile   string   `json:"config_file,omitempty"`
+	OutputFormat string   `json:"output_format,omitempty"`
+	ExtraArgs    []string `json:"extra_args,omitempty"`
+
+	// Progress receives progress events, nil if progress is not needed.
+	// Checkers report a ProgressFile event with the issues of each file.
+	Progress ProgressFunc `json:"-"`
+}
+
+// Targets returns the paths to pass to the checker. Files takes precedence
+// over Paths, and Paths over Path so that a single run can cover several
+// directories.
+func (o CheckOptions) Targets() []string {
+	if len(o.Files) > 0 {
+		return o.Files
+	}
+	return o.Roots()
+}
+
+// Roots returns the paths the check was started on, before resolving them
+// into files
+func (o CheckOptions) Roots() []string {
+	if len(o.Paths) > 0 {
+		return o.Paths
+	}
+	return []string{o.Path}
+}
+
+// maxTargetsLength is the number of bytes of paths passed to a tool on one
+// command line. Linux allows about 2 MB for the arguments and environment,
+// Windows 32K characters for the whole command line.
+func maxTargetsLength() int {
+	if runtime.GOOS == "windows" {
+		return 24 << 10
+	}
+	return 128 << 10
+}
+
+// batchTargets splits targets into batches whose paths add up to at most
+// limit bytes, so that large trees do not exceed the command line limit.
+// Every batch has at least one target.
+func batchTargets(targets []string, limit int) [][]string {
+	var batches [][]string
+	start, length := 0, 0
+	for i, target := range targets {
+		size := len(target) + 1
+		if i > start && length+size > limit {
+			batches = append(batches, targets[start:i])
+			start, length = i, 0
+		}
+		length += size
+	}
+	return append(batches, targets[start:])
 }
@@ -0,0 +1,27 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatchTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+		limit   int
+		want    [][]string
+	}{
+		{"fits", []string{"a.md", "b.md"}, 100, [][]string{{"a.md", "b.md"}}},
+		{"split", []string{"a.md", "b.md", "c.md"}, 10, [][]string{{"a.md", "b.md"}, {"c.md"}}},
+		{"exact", []string{"a.md", "b.md"}, 10, [][]string{{"a.md", "b.md"}}},
+		{"too long", []string{"long-name.md", "a.md"}, 5, [][]string{{"long-name.md"}, {"a.md"}}},
+		{"empty", nil, 10, [][]string{nil}},
+	}
+
+	for _, tt := range tests {
+		if got := batchTargets(tt.targets, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: batchTargets = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// assignFingerprints sets a stable Fingerprint on every issue in result.
+//
+// The fingerprint is a hash of the checker, rule, normalized file path,
+// matched context and the text of the source line the issue is on. The line
+// text anchors the issue without depending on its line number, so an issue
+// keeps its fingerprint when lines are added or removed above it. Identical
+// issues are told apart by their order of occurrence in the file.
+func assignFingerprints(result *models.Result) {
+	lines := newSourceLines()
+	occurrences := make(map[string]int)
+
+	for i := range result.Issues {
+		issue := &result.Issues[i]
+		base := strings.Join([]string{
+			result.Checker,
+			issue.Rule,
+			normalizeIssuePath(issue.File),
+			models.NormalizeText(issue.Context),
+			models.NormalizeText(lines.text(issue.File, issue.Line)),
+		}, "\x00")
+
+		occurrence := occurrences[base]
+		occurrences[base]++
+
+		sum := sha256.Sum256([]byte(base + "\x00" + strconv.Itoa(occurrence)))
+		issue.Fingerprint = hex.EncodeToString(sum[:16])
+	}
+}
+
+// normalizeIssuePath makes a file path comparable across platforms and
+// working directories: slash-separated, cleaned and relative to the working
+// directory when it is inside it
+func normalizeIssuePath(path string) string {
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+				path = rel
+			}
+		}
+	}
+	return models.NormalizePath(path)
+}
+
+// sourceLines reads source files on demand for fingerprinting
+type sourceLines struct {
+	files map[string][]string
+}
+
+func newSourceLines() *sourceLines {
+	return &sourceLines{files: make(map[string][]string)}
+}
+
+// text returns a 1-based line of a file, or an empty string if the file
+// cannot be read or has no such line
+func (s *sourceLines) text(path string, line int) string {
+	lines, ok := s.files[path]
+	if !ok {
+		lines = readLines(path)
+		s.files[path] = lines
+	}
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
+
+// readLines returns the lines of a file, or nil if it cannot be read
+func readLines(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
@@ -0,0 +1,306 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// Rule IDs reported by the link checker
+const (
+	RuleBrokenLink   = "broken-link"
+	RuleBrokenImage  = "broken-image"
+	RuleBrokenAnchor = "broken-anchor"
+)
+
+// schemePattern matches links with a URL scheme such as https: or mailto:
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// LinkChecker implements the Checker interface for internal links.
+// It resolves relative links, image paths and #anchor fragments between
+// Markdown files without any external tool.
+type LinkChecker struct {
+	root string
+	docs map[string]*markdownDocument
+}
+
+func init() {
+	Register(Definition{
+		Name:  "links",
+		Short: "Check internal links, images and anchors",
+		Long: `Check internal links, images and anchors in Markdown files.
+
+The link checker parses every Markdown file under the path and verifies that
+relative links and image paths point to existing files, and that #anchor
+fragments match a heading in the target page. Heading anchors are generated
+with both GitHub and VitePress slug rules, and custom {#id} anchors and HTML
+id/name attributes are also recognized.
+
+External http(s) links are not checked; use the urlcheck checker for those.`,
+		Example: `  # Check links in the default docs/ directory
+  marvin links
+
+  # Check links in a specific directory
+  marvin links ./content
+
+  # Resolve absolute links like /guide/intro against the docs directory
+  marvin links --root docs`,
+		Flags: []Flag{
+			{Name: "root", Usage: "Site root for absolute links like /guide/ (default: the scanned path)"},
+		},
+		New: func(settings Settings) (Checker, error) {
+			return NewLinkChecker(settings.String("root")), nil
+		},
+	})
+}
+
+// NewLinkChecker creates a new link checker. root is the directory that
+// absolute links such as /guide/intro are resolved against.
+func NewLinkChecker(root string) *LinkChecker {
+	return &LinkChecker{
+		root: root,
+		docs: make(map[string]*markdownDocument),
+	}
+}
+
+// Name returns the checker name
+func (c *LinkChecker) Name() string {
+	return "links"
+}
+
+// Validate validates the checker configuration
+func (c *LinkChecker) Validate() error {
+	if c.root == "" {
+		return nil
+	}
+	info, err := os.Stat(c.root)
+	if err != nil {
+		return fmt.Errorf("link root not found: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("link root is not a directory: %s", c.root)
+	}
+	return nil
+}
+
+// Check parses all Markdown files and returns the broken links
+func (c *LinkChecker) Check(ctx context.Context, opts CheckOptions) (*models.Result, error) {
+	c.docs = make(map[string]*markdownDocument)
+
+	root := c.root
+	targets := opts.Targets()
+	if root == "" {
+		root = opts.Roots()[0]
+		if info, err := os.Stat(root); err == nil && !info.IsDir() {
+			root = filepath.Dir(root)
+		}
+	}
+
+	files, err := findMarkdownFiles(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.Result{
+		Checker:   "links",
+		Timestamp: time.Now(),
+		Path:      opts.Path,
+		Issues:    []models.Issue{},
+		Metadata:  make(map[string]interface{}),
+	}
+
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		doc, err := c.document(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		issues := 0
+		for _, link := range doc.Links {
+			if issue := c.checkLink(file, root, link); issue != nil {
+				result.Issues = append(result.Issues, *issue)
+				issues++
+			}
+		}
+		opts.Report(ProgressEvent{Kind: ProgressFile, File: file, Issues: issues})
+	}
+
+	result.Summary.TotalFiles = len(files)
+	result.Recount()
+	assignFingerprints(result)
+
+	// Add metadata
+	result.Metadata["root"] = root
+
+	return result, nil
+}
+
+// checkLink resolves a single link and returns an issue if it is broken
+func (c *LinkChecker) checkLink(file, root string, link markdownLink) *models.Issue {
+	dest := link.Destination
+	if dest == "" || strings.HasPrefix(dest, "//") || schemePattern.MatchString(dest) {
+		return nil
+	}
+
+	// Split off query and fragment
+	target, fragment, _ := strings.Cut(dest, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if decoded, err := url.PathUnescape(target); err == nil {
+		target = decoded
+	}
+	if decoded, err := url.PathUnescape(fragment); err == nil {
+		fragment = decoded
+	}
+
+	newIssue := func(rule, message string) *models.Issue {
+		return &models.Issue{
+			File:     file,
+			Line:     link.Line,
+			Column:   link.Column,
+			Severity: "error",
+			Message:  message,
+			Rule:     rule,
+			Context:  dest,
+		}
+	}
+
+	// Resolve the target file
+	resolved := file
+	if target != "" {
+		var base string
+		if strings.HasPrefix(target, "/") {
+			base = filepath.Join(root, filepath.FromSlash(target))
+		} else {
+			base = filepath.Join(filepath.Dir(file), filepath.FromSlash(target))
+		}
+
+		var found bool
+		resolved, found = resolveLinkTarget(base, strings.HasSuffix(target, "/"))
+		if !found {
+			if link.Image {
+				return newIssue(RuleBrokenImage, fmt.Sprintf("Image not found: %s", target))
+			}
+			return newIssue(RuleBrokenLink, fmt.Sprintf("Link target not found: %s", target))
+		}
+	}
+
+	// Check the anchor
+	if fragment == "" || !isMarkdownFile(resolved) {
+		return nil
+	}
+	doc, err := c.document(resolved)
+	if err != nil {
+		return newIssue(RuleBrokenLink, fmt.Sprintf("Link target cannot be read: %s", target))
+	}
+	if doc.Anchors[fragment] || doc.Anchors[strings.ToLower(fragment)] {
+		return nil
+	}
+
+	if target == "" {
+		return newIssue(RuleBrokenAnchor, fmt.Sprintf("Anchor #%s not found in this file", fragment))
+	}
+	return newIssue(RuleBrokenAnchor, fmt.Sprintf("Anchor #%s not found in %s", fragment, target))
+}
+
+// document returns the parsed Markdown file, parsing it on first use
+func (c *LinkChecker) document(path string) (*markdownDocument, error) {
+	key := filepath.Clean(path)
+	if doc, ok := c.docs[key]; ok {
+		return doc, nil
+	}
+	doc, err := parseMarkdownFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c.docs[key] = doc
+	return doc, nil
+}
+
+// resolveLinkTarget finds the file a link points to. Besides the exact path
+// it tries the conventions of static site generators: extensionless links
+// (guide/intro -> guide/intro.md), .html links to .md sources and directory
+// links to index.md or README.md.
+func resolveLinkTarget(base string, isDir bool) (string, bool) {
+	var candidates []string
+	if !isDir {
+		candidates = append(candidates, base)
+		switch strings.ToLower(filepath.Ext(base)) {
+		case "":
+			for _, ext := range markdownExtensions {
+				candidates = append(candidates, base+ext)
+			}
+		case ".html":
+			stem := strings.TrimSuffix(base, filepath.Ext(base))
+			for _, ext := range markdownExtensions {
+				candidates = append(candidates, stem+ext)
+			}
+		}
+	}
+	candidates = append(candidates,
+		filepath.Join(base, "index.md"),
+		filepath.Join(base, "README.md"),
+	)
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+		return candidate, true
+	}
+
+	// A link to an existing directory without an index page is still valid
+	if info, err := os.Stat(base); err == nil && info.IsDir() {
+		return base, true
+	}
+
+	return "", false
+}
+
+// findMarkdownFiles returns all Markdown files under the given paths.
+// Hidden directories and node_modules are skipped.
+func findMarkdownFiles(paths []string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() {
+				name := info.Name()
+				if path != root && (strings.HasPrefix(name, ".") || name == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if isMarkdownFile(path) && !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	return files, nil
+}
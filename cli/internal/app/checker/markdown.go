@@ -0,0 +1,482 @@
+package checker
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// markdownExtensions are the file extensions treated as Markdown
+var markdownExtensions = []string{".md", ".markdown", ".mdx"}
+
+// markdownLink is a link or image reference found in a Markdown document
+type markdownLink struct {
+	Destination string
+	Line        int
+	Column      int
+	Image       bool
+}
+
+// markdownDocument is the parsed structure of a Markdown file that the
+// native checkers need: its links and the anchors it defines
+type markdownDocument struct {
+	Links   []markdownLink
+	Anchors map[string]bool
+
+	// URLs are autolinks (<https://...>) and bare URLs in the text, which
+	// are not part of Links
+	URLs []markdownLink
+}
+
+var (
+	atxHeadingPattern    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextPattern        = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	fencePattern         = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	indentedCodePattern  = regexp.MustCompile(`^(?: {4}| {0,3}\t)`)
+	listItemPattern      = regexp.MustCompile(`^ {0,3}(?:[-+*]|\d{1,9}[.)])(?:[ \t]|$)`)
+	blockquotePattern    = regexp.MustCompile(`^ {0,3}>`)
+	thematicBreakPattern = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	customIDPattern      = regexp.MustCompile(`[ \t]*\{#([^}\s]+)\}[ \t]*$`)
+	referencePattern     = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ \t]*<?([^\s>]+)>?`)
+	htmlLinkPattern      = regexp.MustCompile(`(?i)<(a|img)\b[^>]*?\b(href|src)[ \t]*=[ \t]*["']([^"']+)["']`)
+	htmlAnchorPattern    = regexp.MustCompile(`(?i)<[a-z][^>]*?\b(?:id|name)[ \t]*=[ \t]*["']([^"']+)["']`)
+	inlineLinkPattern    = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	htmlTagPattern       = regexp.MustCompile(`<[^>]+>`)
+	emphasisPattern      = regexp.MustCompile("[*_`~]+")
+	vitepressSpecialPatt = regexp.MustCompile("[\\s~`!@#$%^&*()\\-_+=\\[\\]{}|\\\\;:\"'“”‘’<>,.?/]+")
+	repeatedDashPattern  = regexp.MustCompile(`-{2,}`)
+	bareURLPattern       = regexp.MustCompile("https?://[^\\s<>\"'`\\[\\]]+")
+)
+
+// isMarkdownFile reports whether path has a Markdown extension
+func isMarkdownFile(path string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range markdownExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMarkdownFile reads and parses a Markdown file
+func parseMarkdownFile(path string) (*markdownDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseMarkdown(string(data)), nil
+}
+
+// parseMarkdown extracts links and anchors from Markdown source. Links in
+// front matter, fenced and indented code blocks, HTML comments and inline
+// code are ignored.
+func parseMarkdown(source string) *markdownDocument {
+	doc := &markdownDocument{
+		Anchors: make(map[string]bool),
+	}
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	githubSlugs := newSlugCounter()
+	vitepressSlugs := newSlugCounter()
+	addHeading := func(text string) {
+		if match := customIDPattern.FindStringSubmatch(text); match != nil {
+			doc.Anchors[match[1]] = true
+			text = customIDPattern.ReplaceAllString(text, "")
+		}
+		plain := headingPlainText(text)
+		doc.Anchors[githubSlugs.next(githubSlug(plain))] = true
+		doc.Anchors[vitepressSlugs.next(vitepressSlug(plain))] = true
+	}
+
+	fence := ""
+	inComment := false
+	start := skipFrontMatter(lines)
+	// paragraph is the previous line if it is paragraph text, which an
+	// underline turns into a setext heading
+	paragraph := ""
+	inList := false
+
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		lineNumber := i + 1
+
+		// Fenced code blocks
+		if fence != "" {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			paragraph = ""
+			continue
+		}
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			fence = match[1]
+			paragraph = ""
+			continue
+		}
+
+		// Indented code blocks; indented lines continue paragraphs and
+		// list items instead
+		if strings.TrimSpace(line) == "" {
+			paragraph = ""
+		} else if indentedCodePattern.MatchString(line) {
+			if paragraph == "" && !inList {
+				continue
+			}
+		} else {
+			inList = listItemPattern.MatchString(line) && !thematicBreakPattern.MatchString(line)
+		}
+
+		// HTML comments
+		line, inComment = stripComments(line, inComment)
+
+		// Headings; a setext underline only follows paragraph text, so that
+		// a thematic break after a heading or list item is not one
+		heading := true
+		if match := atxHeadingPattern.FindStringSubmatch(line); match != nil {
+			addHeading(match[2])
+		} else if setextPattern.MatchString(line) && strings.TrimSpace(paragraph) != "" {
+			addHeading(strings.TrimSpace(paragraph))
+		} else {
+			heading = false
+		}
+
+		// HTML anchors
+		for _, match := range htmlAnchorPattern.FindAllStringSubmatch(line, -1) {
+			doc.Anchors[match[1]] = true
+		}
+
+		code := maskInlineCode(line)
+		doc.Links = append(doc.Links, findInlineLinks(code, lineNumber)...)
+		doc.URLs = append(doc.URLs, findBareURLs(code, lineNumber)...)
+
+		if match := referencePattern.FindStringSubmatchIndex(code); match != nil {
+			doc.Links = append(doc.Links, markdownLink{
+				Destination: code[match[4]:match[5]],
+				Line:        lineNumber,
+				Column:      columnAt(code, match[0]),
+			})
+		}
+
+		for _, match := range htmlLinkPattern.FindAllStringSubmatchIndex(code, -1) {
+			doc.Links = append(doc.Links, markdownLink{
+				Destination: code[match[6]:match[7]],
+				Line:        lineNumber,
+				Column:      columnAt(code, match[0]),
+				Image:       strings.EqualFold(code[match[2]:match[3]], "img"),
+			})
+		}
+
+		paragraph = ""
+		if !heading && isParagraphText(line) {
+			paragraph = line
+		}
+	}
+
+	return doc
+}
+
+// isParagraphText reports whether line is text that can be the content of a
+// setext heading, rather than blank or the start of another block
+func isParagraphText(line string) bool {
+	return strings.TrimSpace(line) != "" &&
+		!listItemPattern.MatchString(line) &&
+		!blockquotePattern.MatchString(line) &&
+		!thematicBreakPattern.MatchString(line)
+}
+
+// findInlineLinks finds inline links and images such as [text](dest "title")
+func findInlineLinks(line string, lineNumber int) []markdownLink {
+	var links []markdownLink
+
+	for offset := 0; ; {
+		idx := strings.Index(line[offset:], "](")
+		if idx < 0 {
+			break
+		}
+		closeBracket := offset + idx
+		destStart := closeBracket + 2
+		offset = destStart
+
+		openBracket := matchingOpenBracket(line, closeBracket)
+		if openBracket < 0 {
+			continue
+		}
+
+		dest, ok := parseLinkDestination(line[destStart:])
+		if !ok || dest == "" {
+			continue
+		}
+
+		image := openBracket > 0 && line[openBracket-1] == '!'
+		start := openBracket
+		if image {
+			start--
+		}
+
+		links = append(links, markdownLink{
+			Destination: dest,
+			Line:        lineNumber,
+			Column:      columnAt(line, start),
+			Image:       image,
+		})
+	}
+
+	return links
+}
+
+// findBareURLs finds autolinks and bare http(s) URLs that are not the
+// destination of an inline link or an HTML attribute
+func findBareURLs(line string, lineNumber int) []markdownLink {
+	var urls []markdownLink
+
+	for _, match := range bareURLPattern.FindAllStringIndex(line, -1) {
+		raw := trimURLPunctuation(line[match[0]:match[1]])
+
+		// Skip destinations of inline links and URLs inside HTML attributes
+		prefix := strings.TrimRight(line[:match[0]], "<")
+		if strings.HasSuffix(prefix, "](") || strings.HasSuffix(prefix, "=\"") || strings.HasSuffix(prefix, "='") {
+			continue
+		}
+
+		urls = append(urls, markdownLink{
+			Destination: raw,
+			Line:        lineNumber,
+			Column:      columnAt(line, match[0]),
+		})
+	}
+
+	return urls
+}
+
+// trimURLPunctuation removes trailing punctuation that is part of the
+// surrounding sentence rather than the URL, including unbalanced ")"
+func trimURLPunctuation(raw string) string {
+	for {
+		trimmed := strings.TrimRight(raw, ".,;:!?*_~")
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if trimmed == raw {
+			return raw
+		}
+		raw = trimmed
+	}
+}
+
+// matchingOpenBracket returns the index of the [ that matches the ] at end
+func matchingOpenBracket(line string, end int) int {
+	depth := 0
+	for i := end; i >= 0; i-- {
+		switch line[i] {
+		case ']':
+			depth++
+		case '[':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseLinkDestination parses the destination of an inline link from the
+// text following "](". Destinations may be wrapped in <> and may contain
+// balanced parentheses.
+func parseLinkDestination(text string) (string, bool) {
+	text = strings.TrimLeft(text, " \t")
+	if strings.HasPrefix(text, "<") {
+		end := strings.IndexByte(text, '>')
+		if end < 0 {
+			return "", false
+		}
+		return text[1:end], true
+	}
+
+	depth := 0
+	for i, r := range text {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth == 0 {
+				return text[:i], true
+			}
+			depth--
+		case r == ' ' || r == '\t':
+			return text[:i], true
+		}
+	}
+	return "", false
+}
+
+// skipFrontMatter returns the index of the first line after YAML front matter
+func skipFrontMatter(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// stripComments replaces HTML comments in line with spaces so that columns
+// are preserved. inComment reports whether the line starts inside a comment
+// opened on a previous line.
+func stripComments(line string, inComment bool) (string, bool) {
+	b := []byte(line)
+	for i := 0; i < len(b); {
+		if inComment {
+			end := strings.Index(string(b[i:]), "-->")
+			if end < 0 {
+				blank(b[i:])
+				return string(b), true
+			}
+			blank(b[i : i+end+3])
+			i += end + 3
+			inComment = false
+			continue
+		}
+		start := strings.Index(string(b[i:]), "<!--")
+		if start < 0 {
+			break
+		}
+		i += start
+		inComment = true
+	}
+	return string(b), inComment
+}
+
+// maskInlineCode replaces inline code spans with spaces so that links inside
+// code are ignored while columns are preserved
+func maskInlineCode(line string) string {
+	if !strings.Contains(line, "`") {
+		return line
+	}
+
+	b := []byte(line)
+	for i := 0; i < len(b); {
+		if b[i] != '`' {
+			i++
+			continue
+		}
+		run := 1
+		for i+run < len(b) && b[i+run] == '`' {
+			run++
+		}
+		delimiter := strings.Repeat("`", run)
+		end := strings.Index(string(b[i+run:]), delimiter)
+		if end < 0 {
+			break
+		}
+		blank(b[i : i+run+end+run])
+		i += run + end + run
+	}
+	return string(b)
+}
+
+// blank replaces every byte in b with a space
+func blank(b []byte) {
+	for i := range b {
+		b[i] = ' '
+	}
+}
+
+// columnAt converts a byte offset in line to a 1-based character column
+func columnAt(line string, offset int) int {
+	return utf8.RuneCountInString(line[:offset]) + 1
+}
+
+// headingPlainText strips inline Markdown and HTML from heading text
+func headingPlainText(text string) string {
+	text = inlineLinkPattern.ReplaceAllString(text, "$1")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = emphasisPattern.ReplaceAllString(text, "")
+	return strings.TrimSpace(text)
+}
+
+// githubSlug converts heading text to an anchor using GitHub's rules:
+// lowercase, drop punctuation and replace spaces with hyphens
+func githubSlug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// vitepressSlug converts heading text to an anchor using VitePress's rules:
+// strip accents, collapse runs of whitespace and punctuation into a single
+// hyphen, trim hyphens, prefix a leading digit with an underscore and lowercase
+func vitepressSlug(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if unicode.Is(unicode.Mn, r) || unicode.IsControl(r) {
+			continue
+		}
+		if base, ok := accentFolds[r]; ok {
+			b.WriteString(base)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	slug := vitepressSpecialPatt.ReplaceAllString(b.String(), "-")
+	slug = repeatedDashPattern.ReplaceAllString(slug, "-")
+	slug = strings.Trim(slug, "-")
+	if slug != "" && slug[0] >= '0' && slug[0] <= '9' {
+		slug = "_" + slug
+	}
+	return strings.ToLower(slug)
+}
+
+// slugCounter makes slugs unique within a document by appending -1, -2, ...
+// to repeated slugs, as GitHub and VitePress do
+type slugCounter map[string]int
+
+func newSlugCounter() slugCounter {
+	return make(slugCounter)
+}
+
+func (c slugCounter) next(slug string) string {
+	count, seen := c[slug]
+	c[slug] = count + 1
+	if !seen {
+		return slug
+	}
+	return slug + "-" + strconv.Itoa(count)
+}
+
+// accentFolds maps precomposed Latin letters to their unaccented form,
+// matching the effect of NFKD normalization followed by removing combining marks
+var accentFolds = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A",
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a",
+	'Ç': "C", 'ç': "c", 'Ć': "C", 'ć': "c", 'Č': "C", 'č': "c",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ě': "E",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ě': "e",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'Ñ': "N", 'ñ': "n", 'Ń': "N", 'ń': "n", 'Ň': "N", 'ň': "n",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ů': "U",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ů': "u",
+	'Ý': "Y", 'ý': "y", 'ÿ': "y",
+	'Ś': "S", 'ś': "s", 'Š': "S", 'š': "s",
+	'Ź': "Z", 'ź': "z", 'Ž': "Z", 'ž': "z", 'Ż': "Z", 'ż': "z",
+	'Ř': "R", 'ř': "r", 'Ť': "T", 'ť': "t", 'Ď': "D", 'ď': "d",
+}
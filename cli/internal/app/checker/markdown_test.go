@@ -0,0 +1,85 @@
+package checker
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// anchorList returns the anchors of a document, sorted
+func anchorList(doc *markdownDocument) []string {
+	var anchors []string
+	for anchor := range doc.Anchors {
+		anchors = append(anchors, anchor)
+	}
+	sort.Strings(anchors)
+	return anchors
+}
+
+// linkDestinations returns the destinations of the links of a document
+func linkDestinations(doc *markdownDocument) []string {
+	var destinations []string
+	for _, link := range doc.Links {
+		destinations = append(destinations, link.Destination)
+	}
+	return destinations
+}
+
+func TestParseMarkdownSetextHeadings(t *testing.T) {
+	source := strings.Join([]string{
+		"# Title",
+		"---",
+		"",
+		"- Item",
+		"---",
+		"",
+		"> Quote",
+		"---",
+		"",
+		"Setext Heading",
+		"---",
+		"",
+		"Other Heading",
+		"===",
+		"",
+		"***",
+		"---",
+	}, "\n")
+
+	doc := parseMarkdown(source)
+	want := []string{"other-heading", "setext-heading", "title"}
+	if got := anchorList(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("anchors = %v, want %v", got, want)
+	}
+}
+
+func TestParseMarkdownIndentedCode(t *testing.T) {
+	source := strings.Join([]string{
+		"Text",
+		"",
+		"    [code](code.md)",
+		"    # Not a heading",
+		"",
+		"\t[tab](tab.md)",
+		"",
+		"Paragraph",
+		"    [continued](continued.md)",
+		"",
+		"- Item",
+		"",
+		"    [in list](list.md)",
+		"",
+		"```",
+		"    [fenced](fenced.md)",
+		"```",
+	}, "\n")
+
+	doc := parseMarkdown(source)
+	if got, want := linkDestinations(doc), []string{"continued.md", "list.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("links = %v, want %v", got, want)
+	}
+	if doc.Anchors["not-a-heading"] {
+		t.Error("heading in an indented code block registered an anchor")
+	}
+}
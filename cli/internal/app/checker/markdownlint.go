@@ -37,6 +37,59 @@ type MarkdownlintIssue struct {
 	Severity         string                 `json:"severity"`
 }
 
+func init() {
+	Register(Definition{
+		Name:  "markdownlint",
+		Short: "Run markdownlint on Markdown files",
+		Long: `Run markdownlint to check Markdown files for style and syntax issues.
+
+markdownlint checks your Markdown files against a set of rules to ensure
+consistent formatting and style. It outputs results in an interactive TUI
+by default, or can output JSON for integration with other tools.
+
+By default, markdownlint scans the docs/ directory. You can specify a
+different path as an argument.`,
+		Example: `  # Scan default docs/ directory with TUI
+  marvin markdownlint
+
+  # Scan specific directory
+  marvin markdownlint ./content
+
+  # Output JSON only
+  marvin markdownlint --json
+
+  # Disable TUI, show plain text
+  marvin markdownlint --no-tui
+
+  # Use custom markdownlint config
+  marvin markdownlint --config .markdownlint.yaml
+
+  # Automatically fix issues where possible
+  marvin markdownlint --fix`,
+		Tool: "markdownlint",
+		ConfigFiles: []string{
+			".markdownlint.jsonc",
+			".markdownlint.yaml",
+			".markdownlint.yml",
+			".markdownlint.json",
+			".markdownlintrc",
+		},
+		Flags: []Flag{
+			{Name: "config", Usage: "markdownlint config file path (default: auto-detect .markdownlint.yaml)"},
+			{Name: "fix", Usage: "Automatically fix issues where possible", Kind: BoolFlag},
+		},
+		New: func(settings Settings) (Checker, error) {
+			return NewMarkdownlintChecker(
+				settings.String("config"),
+				settings.Bool("fix"),
+				settings.ToolPath,
+			), nil
+		},
+		// Fixing changes the files, so its results are not cached
+		Cacheable: func(settings Settings) bool { return !settings.Bool("fix") },
+	})
+}
+
 // NewMarkdownlintChecker creates a new markdownlint checker
 func NewMarkdownlintChecker(configFile string, fix bool, markdownlintPath string) *MarkdownlintChecker {
 	if markdownlintPath == "" {
@@ -63,8 +116,26 @@ func (c *MarkdownlintChecker) Validate() error {
 	return nil
 }
 
-// Check runs markdownlint and returns the results
+// Check runs markdownlint and returns the results. Many targets are checked
+// in several runs, whose output is merged.
 func (c *MarkdownlintChecker) Check(ctx context.Context, opts CheckOptions) (*models.Result, error) {
+	var markdownlintOutput MarkdownlintOutput
+	for _, targets := range batchTargets(opts.Targets(), maxTargetsLength()) {
+		output, err := c.run(ctx, targets, opts.ExtraArgs)
+		if err != nil {
+			return nil, err
+		}
+		markdownlintOutput = append(markdownlintOutput, output...)
+	}
+
+	// Transform to our Result format
+	result := c.transformResult(markdownlintOutput, opts.Path)
+
+	return result, nil
+}
+
+// run runs markdownlint on targets and parses its output
+func (c *MarkdownlintChecker) run(ctx context.Context, targets, extraArgs []string) (MarkdownlintOutput, error) {
 	// Build command arguments
 	// Note: markdownlint-cli2 uses --json, markdownlint-cli uses --json
 	args := []string{}
@@ -79,14 +150,14 @@ func (c *MarkdownlintChecker) Check(ctx context.Context, opts CheckOptions) (*mo
 		args = append(args, "--fix")
 	}
 
-	// Add path to check
-	args = append(args, opts.Path)
+	// Add paths to check
+	args = append(args, targets...)
 	
 	// Add JSON output format
 	args = append(args, "--json")
 
 	// Add any extra arguments
-	args = append(args, opts.ExtraArgs...)
+	args = append(args, extraArgs...)
 
 	// Execute markdownlint
 	cmd := exec.CommandContext(ctx, c.markdownlintPath, args...)
@@ -118,25 +189,7 @@ func (c *MarkdownlintChecker) Check(ctx context.Context, opts CheckOptions) (*mo
 	
 	// If we still have no output, that means no issues were found
 	if len(output) == 0 {
-		// Return empty result (no issues)
-		result := &models.Result{
-			Checker:   "markdownlint",
-			Timestamp: time.Now(),
-			Path:      opts.Path,
-			Summary: models.Summary{
-				TotalFiles:      0,
-				FilesWithIssues: 0,
-				TotalIssues:     0,
-				ErrorCount:      0,
-				WarningCount:    0,
-				InfoCount:       0,
-			},
-			Issues:   []models.Issue{},
-			Metadata: make(map[string]interface{}),
-		}
-		result.Metadata["config_file"] = c.configFile
-		result.Metadata["fix_enabled"] = c.fix
-		return result, nil
+		return nil, nil
 	}
 	
 	// Check if output looks like JSON
@@ -152,10 +205,7 @@ func (c *MarkdownlintChecker) Check(ctx context.Context, opts CheckOptions) (*mo
 		return nil, fmt.Errorf("failed to parse markdownlint output: %w (output: %s)", err, string(output))
 	}
 
-	// Transform to our Result format
-	result := c.transformResult(markdownlintOutput, opts.Path)
-
-	return result, nil
+	return markdownlintOutput, nil
 }
 
 // transformResult converts markdownlint output to our unified Result format
@@ -226,6 +276,8 @@ func (c *MarkdownlintChecker) transformResult(markdownlintOutput MarkdownlintOut
 			Message:  message,
 			Rule:     ruleName,
 			Context:  context,
+			RuleURL:  issue.RuleInformation,
+			Fix:      markdownlintFix(issue, ruleName),
 		}
 
 		result.Issues = append(result.Issues, modelIssue)
@@ -245,5 +297,49 @@ func (c *MarkdownlintChecker) transformResult(markdownlintOutput MarkdownlintOut
 	result.Metadata["config_file"] = c.configFile
 	result.Metadata["fix_enabled"] = c.fix
 
+	assignFingerprints(result)
+
 	return result
 }
+
+// markdownlintFix turns the fixInfo of an issue into a fix. fixInfo edits
+// one line: it deletes deleteCount characters at editColumn and inserts
+// insertText, or removes the whole line if deleteCount is -1.
+func markdownlintFix(issue MarkdownlintIssue, rule string) *models.Fix {
+	if issue.FixInfo == nil {
+		return nil
+	}
+	number := func(name string, fallback int) int {
+		if value, ok := issue.FixInfo[name].(float64); ok {
+			return int(value)
+		}
+		return fallback
+	}
+
+	line := number("lineNumber", issue.LineNumber)
+	column := number("editColumn", 1)
+	deleteCount := number("deleteCount", 0)
+	insertText, _ := issue.FixInfo["insertText"].(string)
+
+	edit := models.Edit{
+		Line:      line,
+		Column:    column,
+		EndLine:   line,
+		EndColumn: column + deleteCount,
+		NewText:   insertText,
+	}
+	if deleteCount < 0 {
+		edit.Column = 1
+		edit.EndLine = line + 1
+		edit.EndColumn = 1
+		edit.NewText = ""
+	}
+	if line < 1 || edit.Column < 1 {
+		return nil
+	}
+
+	return &models.Fix{
+		Description: "Fix " + rule,
+		Edits:       []models.Edit{edit},
+	}
+}
@@ -0,0 +1,240 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+const (
+	// PluginPrefix is the executable name prefix of checker plugins.
+	// A plugin named marvin-checker-spelling provides the "spelling" checker.
+	PluginPrefix = "marvin-checker-"
+
+	// PluginDir is the project-local directory searched for plugins
+	PluginDir = ".marvin/plugins"
+
+	// PluginProtocolVersion is the version of the plugin protocol, passed to
+	// plugins in the MARVIN_PLUGIN_PROTOCOL environment variable
+	PluginProtocolVersion = "1"
+)
+
+// PluginChecker implements the Checker interface for external executables.
+//
+// The plugin protocol is:
+//   - Marvin runs the executable with CheckOptions encoded as JSON on stdin
+//   - The plugin writes a models.Result JSON document to stdout
+//   - A non-zero exit code is only treated as a failure if stdout is empty;
+//     plugins may exit non-zero to signal that issues were found
+type PluginChecker struct {
+	name       string
+	pluginPath string
+	configFile string
+}
+
+// NewPluginChecker creates a new checker for the plugin executable at pluginPath
+func NewPluginChecker(name, pluginPath, configFile string) *PluginChecker {
+	return &PluginChecker{
+		name:       name,
+		pluginPath: pluginPath,
+		configFile: configFile,
+	}
+}
+
+// Name returns the checker name
+func (c *PluginChecker) Name() string {
+	return c.name
+}
+
+// Validate validates the checker configuration
+func (c *PluginChecker) Validate() error {
+	info, err := os.Stat(c.pluginPath)
+	if err != nil {
+		return fmt.Errorf("plugin %s not found: %w", c.name, err)
+	}
+	if !isExecutable(info) {
+		return fmt.Errorf("plugin %s is not executable: %s", c.name, c.pluginPath)
+	}
+	return nil
+}
+
+// Check runs the plugin and returns the results
+func (c *PluginChecker) Check(ctx context.Context, opts CheckOptions) (*models.Result, error) {
+	if opts.ConfigFile == "" {
+		opts.ConfigFile = c.configFile
+	}
+
+	input, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin input: %w", err)
+	}
+
+	// Execute plugin
+	cmd := exec.CommandContext(ctx, c.pluginPath)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(),
+		"MARVIN_PLUGIN_PROTOCOL="+PluginProtocolVersion,
+		"MARVIN_CHECKER="+c.name,
+	)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, fmt.Errorf("failed to execute plugin %s: %w", c.name, err)
+		}
+		// A non-zero exit code with output just means issues were found
+		if stdout.Len() == 0 {
+			return nil, fmt.Errorf("plugin %s failed: %s", c.name, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	// Parse plugin output
+	var result models.Result
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin %s output: %w", c.name, err)
+	}
+
+	c.normalizeResult(&result, opts)
+
+	return &result, nil
+}
+
+// normalizeResult fills in fields the plugin may have omitted and recomputes
+// the summary so plugin results are consistent with built-in checkers
+func (c *PluginChecker) normalizeResult(result *models.Result, opts CheckOptions) {
+	result.Checker = c.name
+	if result.Timestamp.IsZero() {
+		result.Timestamp = time.Now()
+	}
+	if result.Path == "" {
+		result.Path = opts.Path
+	}
+	if result.Issues == nil {
+		result.Issues = []models.Issue{}
+	}
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+
+	for i := range result.Issues {
+		result.Issues[i].Severity = normalizeSeverity(result.Issues[i].Severity)
+	}
+	result.Recount()
+	assignFingerprints(result)
+
+	result.Metadata["plugin"] = c.pluginPath
+}
+
+// PluginSearchPaths returns the directories searched for plugins: the
+// project-local plugin directory first, then every directory on PATH
+func PluginSearchPaths() []string {
+	dirs := []string{PluginDir}
+	return append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+// DiscoverPlugins finds checker plugins in dirs and returns their paths keyed
+// by checker name. When several directories contain the same plugin, the
+// first one wins.
+func DiscoverPlugins(dirs []string) map[string]string {
+	plugins := make(map[string]string)
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name := pluginName(entry.Name())
+			if name == "" || entry.IsDir() {
+				continue
+			}
+			if _, exists := plugins[name]; exists {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			info, err := os.Stat(path)
+			if err != nil || !isExecutable(info) {
+				continue
+			}
+			plugins[name] = path
+		}
+	}
+
+	return plugins
+}
+
+// RegisterPlugins registers a checker for every plugin found in the plugin
+// search paths. Plugins never replace built-in checkers or reserved names.
+func RegisterPlugins(reserved map[string]bool) {
+	for name, path := range DiscoverPlugins(PluginSearchPaths()) {
+		if _, exists := Lookup(name); exists || reserved[name] {
+			continue
+		}
+
+		pluginPath := path
+		Register(Definition{
+			Name:  name,
+			Short: fmt.Sprintf("Run the %s checker plugin", name),
+			Long: fmt.Sprintf(`Run the %s checker plugin.
+
+The plugin is provided by the external executable:
+  %s
+
+Marvin passes the check options to the plugin as JSON on stdin and reads
+the results back from its stdout.`, name, pluginPath),
+			Plugin: pluginPath,
+			Flags: []Flag{
+				{Name: "config", Usage: "Config file path passed to the plugin"},
+			},
+			New: func(settings Settings) (Checker, error) {
+				return NewPluginChecker(name, pluginPath, settings.String("config")), nil
+			},
+		})
+	}
+}
+
+// pluginName returns the checker name for a plugin file name, or an empty
+// string if the file is not a plugin
+func pluginName(fileName string) string {
+	if !strings.HasPrefix(fileName, PluginPrefix) {
+		return ""
+	}
+	name := strings.TrimPrefix(fileName, PluginPrefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name
+}
+
+// isExecutable reports whether a file can be executed
+func isExecutable(info os.FileInfo) bool {
+	if info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(info.Name())) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return info.Mode()&0111 != 0
+}
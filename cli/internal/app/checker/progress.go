@@ -0,0 +1,127 @@
+package checker
+
+import (
+	"sync"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// ProgressKind is the kind of a progress event
+type ProgressKind string
+
+const (
+	// ProgressStarted is reported once before any file is checked
+	ProgressStarted ProgressKind = "started"
+
+	// ProgressFile is reported after each checked file
+	ProgressFile ProgressKind = "file"
+
+	// ProgressFinished is reported once after a successful check
+	ProgressFinished ProgressKind = "finished"
+
+	// ProgressFailed is reported once if the check failed
+	ProgressFailed ProgressKind = "failed"
+)
+
+// ProgressEvent reports the progress of a check
+type ProgressEvent struct {
+	Kind    ProgressKind `json:"kind"`
+	Checker string       `json:"checker"`
+
+	// File is the checked file of ProgressFile events
+	File string `json:"file,omitempty"`
+
+	// Done is the number of checked files out of Total
+	Done  int `json:"done"`
+	Total int `json:"total"`
+
+	// Issues is the number of issues found so far. Checkers report the
+	// issues of File only; the Tracker turns them into a running total.
+	Issues int `json:"issues"`
+
+	// Error is the reason a check failed
+	Error string `json:"error,omitempty"`
+}
+
+// ProgressFunc receives progress events. It may be called from several
+// goroutines.
+type ProgressFunc func(event ProgressEvent)
+
+// Report sends a progress event if progress was requested
+func (o CheckOptions) Report(event ProgressEvent) {
+	if o.Progress != nil {
+		o.Progress(event)
+	}
+}
+
+// Tracker keeps the running totals of a check and reports them as progress
+// events
+type Tracker struct {
+	mu      sync.Mutex
+	report  ProgressFunc
+	checker string
+	done    int
+	total   int
+	issues  int
+}
+
+// NewTracker creates a tracker for a check of total files. report may be
+// nil, in which case nothing is reported.
+func NewTracker(checker string, total int, report ProgressFunc) *Tracker {
+	return &Tracker{report: report, checker: checker, total: total}
+}
+
+// Start reports that the check started
+func (t *Tracker) Start() {
+	t.send(ProgressEvent{Kind: ProgressStarted})
+}
+
+// Report adds the issues of a checked file reported by a checker to the
+// running totals. It is used as CheckOptions.Progress of the checker.
+func (t *Tracker) Report(event ProgressEvent) {
+	if event.Kind != ProgressFile {
+		return
+	}
+	t.FileDone(event.File, event.Issues)
+}
+
+// FileDone records a checked file and its number of issues
+func (t *Tracker) FileDone(file string, issues int) {
+	t.mu.Lock()
+	t.done++
+	t.issues += issues
+	t.mu.Unlock()
+
+	t.send(ProgressEvent{Kind: ProgressFile, File: file})
+}
+
+// Finish reports that the check finished with result
+func (t *Tracker) Finish(result *models.Result) {
+	t.mu.Lock()
+	t.done = t.total
+	t.issues = result.Summary.TotalIssues
+	t.mu.Unlock()
+
+	t.send(ProgressEvent{Kind: ProgressFinished})
+}
+
+// Fail reports that the check failed
+func (t *Tracker) Fail(err error) {
+	t.send(ProgressEvent{Kind: ProgressFailed, Error: err.Error()})
+}
+
+// send fills in the totals of an event and reports it
+func (t *Tracker) send(event ProgressEvent) {
+	if t.report == nil {
+		return
+	}
+
+	t.mu.Lock()
+	event.Checker = t.checker
+	event.Done = t.done
+	event.Total = t.total
+	event.Issues = t.issues
+	t.mu.Unlock()
+
+	t.report(event)
+}
@@ -0,0 +1,154 @@
+package checker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// FlagKind identifies the type of a checker flag
+type FlagKind int
+
+const (
+	// StringFlag is a flag that takes a string value
+	StringFlag FlagKind = iota
+	// BoolFlag is a flag that is either set or not
+	BoolFlag
+)
+
+// Flag describes a command-line flag accepted by a checker
+type Flag struct {
+	Name    string
+	Usage   string
+	Kind    FlagKind
+	Default string
+}
+
+// Settings holds the resolved flag values and tool path passed to a checker factory
+type Settings struct {
+	// ToolPath is the path of the external tool, empty if the checker needs none
+	ToolPath string
+
+	// Values maps flag names to their resolved values
+	Values map[string]string
+}
+
+// String returns the value of a string flag
+func (s Settings) String(name string) string {
+	return s.Values[name]
+}
+
+// Bool returns the value of a bool flag
+func (s Settings) Bool(name string) bool {
+	value, _ := strconv.ParseBool(s.Values[name])
+	return value
+}
+
+// Definition describes a checker known to Marvin. Cobra commands, help output,
+// dependency checks and the list of available checkers are derived from it.
+type Definition struct {
+	// Name is the checker and command name
+	Name string
+
+	// Short is a one-line description shown in help listings
+	Short string
+
+	// Long is the detailed command description
+	Long string
+
+	// Example contains usage examples for the command
+	Example string
+
+	// Tool is the external tool the checker runs, empty if none is required
+	Tool string
+
+	// Plugin is the path of the plugin executable for external checkers
+	Plugin string
+
+	// DefaultPath is scanned when no path is given (default: docs/)
+	DefaultPath string
+
+	// Extensions are the file extensions the checker checks (default:
+	// Markdown)
+	Extensions []string
+
+	// ConfigFiles are tool config files auto-detected when the config flag is
+	// empty, in order of preference
+	ConfigFiles []string
+
+	// Flags are the checker-specific command-line flags
+	Flags []Flag
+
+	// New creates the checker from resolved settings
+	New func(settings Settings) (Checker, error)
+
+	// Cacheable reports whether the issues of each file depend only on the
+	// file content and the settings, so that they can be cached between
+	// runs. Nil means the results are never cached.
+	Cacheable func(settings Settings) bool
+
+	// ConfigInputs returns the files and directories the tool reads besides
+	// its config file, such as style directories. Their content is part of
+	// the cache key.
+	ConfigInputs func(settings Settings) []string
+}
+
+// FileExtensions returns the file extensions the checker checks
+func (d Definition) FileExtensions() []string {
+	if len(d.Extensions) > 0 {
+		return d.Extensions
+	}
+	return markdownExtensions
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Definition)
+)
+
+// Register adds a checker definition to the registry.
+// It panics if a checker with the same name is already registered.
+func Register(def Definition) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[def.Name]; exists {
+		panic(fmt.Sprintf("checker %q is already registered", def.Name))
+	}
+	registry[def.Name] = def
+}
+
+// Lookup returns the definition of the named checker
+func Lookup(name string) (Definition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	def, ok := registry[name]
+	return def, ok
+}
+
+// Definitions returns all registered checker definitions sorted by name
+func Definitions() []Definition {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	defs := make([]Definition, 0, len(registry))
+	for _, def := range registry {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+	return defs
+}
+
+// Names returns the names of all registered checkers sorted alphabetically
+func Names() []string {
+	defs := Definitions()
+	names := make([]string, len(defs))
+	for i, def := range defs {
+		names[i] = def.Name
+	}
+	return names
+}
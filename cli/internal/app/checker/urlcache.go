@@ -0,0 +1,137 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// urlCacheFile is the name of the URL check cache inside the cache directory
+const urlCacheFile = "urlcheck.json"
+
+// urlStatus is the outcome of checking a single URL
+type urlStatus struct {
+	// StatusCode is the final HTTP status code, 0 if no response was received
+	StatusCode int `json:"status_code"`
+
+	// RedirectStatus is the status code of the first redirect, 0 if none
+	RedirectStatus int `json:"redirect_status,omitempty"`
+
+	// FinalURL is the URL after following redirects
+	FinalURL string `json:"final_url,omitempty"`
+
+	// Error is set when no response was received
+	Error string `json:"error,omitempty"`
+
+	// Timeout reports whether the request timed out
+	Timeout bool `json:"timeout,omitempty"`
+
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// cacheable reports whether the status is stable enough to be cached.
+// Timeouts, network errors, rate limiting and server errors are transient
+// and are checked again on the next run.
+func (s urlStatus) cacheable() bool {
+	if s.Error != "" || s.Timeout {
+		return false
+	}
+	return s.StatusCode < 500 && s.StatusCode != 429
+}
+
+// urlCache stores URL check results on disk so that repeated runs only
+// check URLs whose cached result has expired
+type urlCache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	entries map[string]urlStatus
+}
+
+// urlCacheDocument is the on-disk format of the URL cache
+type urlCacheDocument struct {
+	Version int                  `json:"version"`
+	Entries map[string]urlStatus `json:"entries"`
+}
+
+// loadURLCache reads the cache from dir. A missing or unreadable cache file
+// results in an empty cache. A ttl of zero disables caching.
+func loadURLCache(dir string, ttl time.Duration) *urlCache {
+	cache := &urlCache{
+		ttl:     ttl,
+		entries: make(map[string]urlStatus),
+	}
+	if dir == "" || ttl <= 0 {
+		return cache
+	}
+	cache.path = filepath.Join(dir, urlCacheFile)
+
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		return cache
+	}
+
+	var doc urlCacheDocument
+	if err := json.Unmarshal(data, &doc); err != nil || doc.Entries == nil {
+		return cache
+	}
+	cache.entries = doc.Entries
+
+	return cache
+}
+
+// get returns the cached status for a URL if it has not expired
+func (c *urlCache) get(rawURL string, now time.Time) (urlStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status, ok := c.entries[rawURL]
+	if !ok || c.ttl <= 0 || now.Sub(status.CheckedAt) > c.ttl {
+		return urlStatus{}, false
+	}
+	return status, true
+}
+
+// put stores the status for a URL if it is cacheable
+func (c *urlCache) put(rawURL string, status urlStatus) {
+	if !status.cacheable() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[rawURL] = status
+}
+
+// save writes the cache to disk, dropping expired entries
+func (c *urlCache) save(now time.Time) error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for rawURL, status := range c.entries {
+		if now.Sub(status.CheckedAt) > c.ttl {
+			delete(c.entries, rawURL)
+		}
+	}
+
+	data, err := json.MarshalIndent(urlCacheDocument{Version: 1, Entries: c.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal URL cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write URL cache: %w", err)
+	}
+
+	return nil
+}
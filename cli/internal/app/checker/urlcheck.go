@@ -0,0 +1,631 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// Rule IDs reported by the URL checker
+const (
+	RuleURLRedirect    = "url-redirect"
+	RuleURLClientError = "url-client-error"
+	RuleURLServerError = "url-server-error"
+	RuleURLRateLimited = "url-rate-limited"
+	RuleURLTimeout     = "url-timeout"
+	RuleURLUnreachable = "url-unreachable"
+)
+
+const (
+	// maxRedirects is the number of redirects followed before giving up
+	maxRedirects = 10
+
+	// urlCheckUserAgent identifies Marvin to the checked servers
+	urlCheckUserAgent = "marvin-urlcheck/0.1 (+https://github.com/svx/marvin)"
+)
+
+// URLChecker implements the Checker interface for external http(s) links.
+// URLs are checked by a bounded pool of workers, requests to the same host
+// are spaced out, transient failures are retried and results are cached.
+type URLChecker struct {
+	concurrency  int
+	retries      int
+	timeout      time.Duration
+	hostDelay    time.Duration
+	retryBackoff time.Duration
+	cacheDir     string
+	cacheTTL     time.Duration
+	exclude      *regexp.Regexp
+	client       *http.Client
+}
+
+// urlOccurrence is a place in the docs where a URL is used
+type urlOccurrence struct {
+	file   string
+	line   int
+	column int
+}
+
+func init() {
+	Register(Definition{
+		Name:  "urlcheck",
+		Short: "Check external http(s) links",
+		Long: `Check external http(s) links in Markdown files.
+
+URLs are extracted from links, images, autolinks and bare URLs, and checked
+with a bounded pool of workers. Requests to the same host are spaced out by
+--host-delay, and timeouts, rate limiting and server errors are retried.
+
+Results are cached in the cache directory for --cache-ttl so that repeated
+runs only check new or expired URLs. Set --cache-ttl 0 to disable the cache.
+
+Rules:
+  url-redirect       The URL redirects to another location
+  url-client-error   The server responded with a 4xx status
+  url-rate-limited   The server kept responding with 429 Too Many Requests
+  url-server-error   The server responded with a 5xx status
+  url-timeout        The request timed out
+  url-unreachable    The server could not be reached`,
+		Example: `  # Check external links in the default docs/ directory
+  marvin urlcheck
+
+  # Use more workers and a shorter timeout
+  marvin urlcheck --concurrency 16 --timeout 5s
+
+  # Skip local and example URLs
+  marvin urlcheck --exclude 'localhost|example\.com'
+
+  # Ignore the cache
+  marvin urlcheck --cache-ttl 0`,
+		Flags: []Flag{
+			{Name: "concurrency", Usage: "Number of URLs checked in parallel", Default: "8"},
+			{Name: "retries", Usage: "Retries for timeouts, rate limiting and server errors", Default: "2"},
+			{Name: "timeout", Usage: "Timeout per request", Default: "10s"},
+			{Name: "host-delay", Usage: "Minimum delay between requests to the same host", Default: "250ms"},
+			{Name: "cache-dir", Usage: "Directory for the URL cache", Default: ".marvin/cache"},
+			{Name: "cache-ttl", Usage: "How long checked URLs are cached (0 disables the cache)", Default: "24h"},
+			{Name: "exclude", Usage: "Regular expression of URLs to skip"},
+		},
+		New: newURLCheckerFromSettings,
+	})
+}
+
+// newURLCheckerFromSettings creates a URL checker from resolved flag values
+func newURLCheckerFromSettings(settings Settings) (Checker, error) {
+	concurrency, err := strconv.Atoi(settings.String("concurrency"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid concurrency: %w", err)
+	}
+	retries, err := strconv.Atoi(settings.String("retries"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid retries: %w", err)
+	}
+	timeout, err := time.ParseDuration(settings.String("timeout"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout: %w", err)
+	}
+	hostDelay, err := time.ParseDuration(settings.String("host-delay"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid host-delay: %w", err)
+	}
+	cacheTTL, err := time.ParseDuration(settings.String("cache-ttl"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache-ttl: %w", err)
+	}
+
+	c := NewURLChecker(concurrency, retries, timeout, hostDelay, settings.String("cache-dir"), cacheTTL)
+	if pattern := settings.String("exclude"); pattern != "" {
+		exclude, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+		}
+		c.exclude = exclude
+	}
+
+	return c, nil
+}
+
+// NewURLChecker creates a new URL checker
+func NewURLChecker(concurrency, retries int, timeout, hostDelay time.Duration, cacheDir string, cacheTTL time.Duration) *URLChecker {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if retries < 0 {
+		retries = 0
+	}
+	return &URLChecker{
+		concurrency:  concurrency,
+		retries:      retries,
+		timeout:      timeout,
+		hostDelay:    hostDelay,
+		retryBackoff: 500 * time.Millisecond,
+		cacheDir:     cacheDir,
+		cacheTTL:     cacheTTL,
+		client: &http.Client{
+			// Redirects are followed manually so they can be reported
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// SetHTTPClient replaces the HTTP client used for requests, for example to
+// check against a test server. Redirects are followed by the checker itself,
+// so the client should not follow them.
+func (c *URLChecker) SetHTTPClient(client *http.Client) {
+	c.client = client
+}
+
+// Name returns the checker name
+func (c *URLChecker) Name() string {
+	return "urlcheck"
+}
+
+// Validate validates the checker configuration
+func (c *URLChecker) Validate() error {
+	if c.timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if c.hostDelay < 0 {
+		return fmt.Errorf("host delay must not be negative")
+	}
+	return nil
+}
+
+// Check extracts all external URLs and returns those that are broken
+func (c *URLChecker) Check(ctx context.Context, opts CheckOptions) (*models.Result, error) {
+	files, err := findMarkdownFiles(opts.Targets())
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Collect URLs and where they are used
+	occurrences := make(map[string][]urlOccurrence)
+	for _, file := range files {
+		doc, err := parseMarkdownFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		seen := make(map[string]bool)
+		for _, link := range append(doc.Links, doc.URLs...) {
+			if !c.isCheckable(link.Destination) {
+				continue
+			}
+			key := fmt.Sprintf("%d:%s", link.Line, link.Destination)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			occurrences[link.Destination] = append(occurrences[link.Destination], urlOccurrence{
+				file:   file,
+				line:   link.Line,
+				column: link.Column,
+			})
+		}
+	}
+
+	// 2. Check URLs that are not cached
+	cache := loadURLCache(c.cacheDir, c.cacheTTL)
+	statuses := make(map[string]urlStatus, len(occurrences))
+	var pending []string
+	now := time.Now()
+	for rawURL := range occurrences {
+		if status, ok := cache.get(rawURL, now); ok {
+			statuses[rawURL] = status
+			continue
+		}
+		pending = append(pending, rawURL)
+	}
+	sort.Strings(pending)
+	cacheHits := len(statuses)
+
+	// A file is checked once all of its URLs are
+	progress := newURLProgress(opts, files, occurrences, statuses)
+	for rawURL, status := range c.checkURLs(ctx, pending, progress.checked) {
+		statuses[rawURL] = status
+		cache.put(rawURL, status)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := cache.save(time.Now()); err != nil {
+		return nil, err
+	}
+
+	// 3. Report issues for every occurrence of a broken URL
+	result := &models.Result{
+		Checker:   "urlcheck",
+		Timestamp: time.Now(),
+		Path:      opts.Path,
+		Issues:    []models.Issue{},
+		Metadata:  make(map[string]interface{}),
+	}
+
+	for rawURL, uses := range occurrences {
+		rule, severity, message := classifyURLStatus(statuses[rawURL])
+		if rule == "" {
+			continue
+		}
+		for _, use := range uses {
+			result.Issues = append(result.Issues, models.Issue{
+				File:     use.file,
+				Line:     use.line,
+				Column:   use.column,
+				Severity: severity,
+				Message:  message,
+				Rule:     rule,
+				Context:  rawURL,
+			})
+		}
+	}
+	sortIssues(result.Issues)
+
+	result.Summary.TotalFiles = len(files)
+	result.Recount()
+	assignFingerprints(result)
+
+	// Add metadata
+	result.Metadata["unique_urls"] = len(occurrences)
+	result.Metadata["checked_urls"] = len(pending)
+	result.Metadata["cache_hits"] = cacheHits
+
+	return result, nil
+}
+
+// isCheckable reports whether a link destination is an external URL to check
+func (c *URLChecker) isCheckable(dest string) bool {
+	lower := strings.ToLower(dest)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return false
+	}
+	if c.exclude != nil && c.exclude.MatchString(dest) {
+		return false
+	}
+	return true
+}
+
+// checkURLs checks URLs with a bounded pool of workers. checked is called
+// with the status of each URL, one call at a time.
+func (c *URLChecker) checkURLs(ctx context.Context, urls []string, checked func(rawURL string, status urlStatus)) map[string]urlStatus {
+	statuses := make(map[string]urlStatus, len(urls))
+	var mu sync.Mutex
+
+	limiter := newHostLimiter(c.hostDelay)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rawURL := range jobs {
+				status := c.checkURL(ctx, limiter, rawURL)
+				mu.Lock()
+				statuses[rawURL] = status
+				checked(rawURL, status)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, rawURL := range urls {
+		select {
+		case jobs <- rawURL:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return statuses
+}
+
+// urlProgress reports a ProgressFile event for each file once all URLs used
+// in it are checked
+type urlProgress struct {
+	opts        CheckOptions
+	occurrences map[string][]urlOccurrence
+
+	// remaining counts the unchecked URLs of each file
+	remaining map[string]int
+
+	// issues counts the broken URL occurrences of each file
+	issues map[string]int
+}
+
+// newURLProgress reports the files whose URLs all have a known status and
+// tracks the others
+func newURLProgress(opts CheckOptions, files []string, occurrences map[string][]urlOccurrence, statuses map[string]urlStatus) *urlProgress {
+	p := &urlProgress{
+		opts:        opts,
+		occurrences: occurrences,
+		remaining:   make(map[string]int),
+		issues:      make(map[string]int),
+	}
+
+	for rawURL, uses := range occurrences {
+		if status, ok := statuses[rawURL]; ok {
+			p.count(uses, status)
+			continue
+		}
+		for file := range usedIn(uses) {
+			p.remaining[file]++
+		}
+	}
+	for _, file := range files {
+		if p.remaining[file] == 0 {
+			p.report(file)
+		}
+	}
+
+	return p
+}
+
+// checked records the status of a checked URL and reports the files that
+// have no unchecked URLs left
+func (p *urlProgress) checked(rawURL string, status urlStatus) {
+	uses := p.occurrences[rawURL]
+	p.count(uses, status)
+	for file := range usedIn(uses) {
+		p.remaining[file]--
+		if p.remaining[file] == 0 {
+			p.report(file)
+		}
+	}
+}
+
+// count adds the occurrences of a URL to the issues of their files if the
+// URL is broken
+func (p *urlProgress) count(uses []urlOccurrence, status urlStatus) {
+	if rule, _, _ := classifyURLStatus(status); rule == "" {
+		return
+	}
+	for _, use := range uses {
+		p.issues[use.file]++
+	}
+}
+
+// report reports a checked file
+func (p *urlProgress) report(file string) {
+	p.opts.Report(ProgressEvent{Kind: ProgressFile, File: file, Issues: p.issues[file]})
+}
+
+// usedIn returns the files of URL occurrences
+func usedIn(uses []urlOccurrence) map[string]bool {
+	files := make(map[string]bool, len(uses))
+	for _, use := range uses {
+		files[use.file] = true
+	}
+	return files
+}
+
+// checkURL checks a single URL, retrying transient failures
+func (c *URLChecker) checkURL(ctx context.Context, limiter *hostLimiter, rawURL string) urlStatus {
+	var status urlStatus
+	var retryAfter time.Duration
+
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			backoff := c.retryBackoff << (attempt - 1)
+			if retryAfter > backoff {
+				backoff = retryAfter
+			}
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return urlStatus{Error: ctx.Err().Error(), CheckedAt: time.Now()}
+			}
+		}
+
+		status, retryAfter = c.follow(ctx, limiter, rawURL)
+		if !isTransient(status) {
+			break
+		}
+	}
+
+	return status
+}
+
+// follow requests a URL and follows redirects manually, recording the first
+// redirect status and the final URL
+func (c *URLChecker) follow(ctx context.Context, limiter *hostLimiter, rawURL string) (urlStatus, time.Duration) {
+	status := urlStatus{CheckedAt: time.Now()}
+	current := rawURL
+
+	for hop := 0; hop <= maxRedirects; hop++ {
+		code, location, retryAfter, err := c.request(ctx, limiter, current)
+		if err != nil {
+			status.Error = err.Error()
+			status.Timeout = isTimeout(err)
+			return status, 0
+		}
+
+		status.StatusCode = code
+		status.FinalURL = current
+		if code < 300 || code >= 400 || location == "" {
+			return status, retryAfter
+		}
+
+		if status.RedirectStatus == 0 {
+			status.RedirectStatus = code
+		}
+		next, err := resolveLocation(current, location)
+		if err != nil {
+			status.Error = fmt.Sprintf("invalid redirect location %q", location)
+			return status, 0
+		}
+		current = next
+	}
+
+	status.Error = "too many redirects"
+	return status, 0
+}
+
+// request performs a HEAD request and falls back to GET when the server
+// rejects HEAD or reports an error, since GET is authoritative
+func (c *URLChecker) request(ctx context.Context, limiter *hostLimiter, rawURL string) (int, string, time.Duration, error) {
+	code, location, retryAfter, err := c.do(ctx, limiter, http.MethodHead, rawURL)
+	if err == nil && code < 400 {
+		return code, location, retryAfter, nil
+	}
+	if err != nil && isTimeout(err) {
+		return 0, "", 0, err
+	}
+	return c.do(ctx, limiter, http.MethodGet, rawURL)
+}
+
+// do sends a single request, waiting for the host rate limit first
+func (c *URLChecker) do(ctx context.Context, limiter *hostLimiter, method, rawURL string) (int, string, time.Duration, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	if err := limiter.wait(ctx, parsed.Host); err != nil {
+		return 0, "", 0, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, method, rawURL, nil)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	req.Header.Set("User-Agent", urlCheckUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.CopyN(io.Discard, resp.Body, 64*1024)
+
+	return resp.StatusCode, resp.Header.Get("Location"), parseRetryAfter(resp.Header.Get("Retry-After")), nil
+}
+
+// classifyURLStatus maps a URL status to a rule, severity and message.
+// It returns an empty rule if the URL is fine.
+func classifyURLStatus(status urlStatus) (string, string, string) {
+	switch {
+	case status.Timeout:
+		return RuleURLTimeout, "warning", "Request timed out"
+	case status.Error != "":
+		return RuleURLUnreachable, "error", fmt.Sprintf("URL is unreachable: %s", status.Error)
+	case status.StatusCode == 429:
+		return RuleURLRateLimited, "warning", "Server responded with 429 Too Many Requests"
+	case status.StatusCode >= 500:
+		return RuleURLServerError, "error", fmt.Sprintf("Server error: %d %s", status.StatusCode, http.StatusText(status.StatusCode))
+	case status.StatusCode >= 400:
+		return RuleURLClientError, "error", fmt.Sprintf("Broken URL: %d %s", status.StatusCode, http.StatusText(status.StatusCode))
+	case status.RedirectStatus != 0:
+		return RuleURLRedirect, "info", fmt.Sprintf("URL redirects (%d) to %s", status.RedirectStatus, status.FinalURL)
+	}
+	return "", "", ""
+}
+
+// isTransient reports whether a failed check should be retried
+func isTransient(status urlStatus) bool {
+	return status.Timeout || status.Error != "" || status.StatusCode == 429 || status.StatusCode >= 500
+}
+
+// isTimeout reports whether err is a timeout
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// resolveLocation resolves a redirect Location header against the request URL
+func resolveLocation(current, location string) (string, error) {
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+	next, err := base.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	return next.String(), nil
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// sortIssues orders issues by file, line and column
+func sortIssues(issues []models.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+}
+
+// hostLimiter spaces out requests to the same host
+type hostLimiter struct {
+	mu    sync.Mutex
+	delay time.Duration
+	next  map[string]time.Time
+}
+
+func newHostLimiter(delay time.Duration) *hostLimiter {
+	return &hostLimiter{
+		delay: delay,
+		next:  make(map[string]time.Time),
+	}
+}
+
+// wait blocks until a request to host is allowed
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	if l.delay <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.delay)
+	l.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,285 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// newTestURLChecker creates a URL checker without a cache that sends its
+// requests to srv
+func newTestURLChecker(srv *httptest.Server, retries int, timeout, hostDelay time.Duration) *URLChecker {
+	c := NewURLChecker(4, retries, timeout, hostDelay, "", 0)
+	c.retryBackoff = time.Millisecond
+
+	client := srv.Client()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	c.SetHTTPClient(client)
+	return c
+}
+
+// writeDoc writes a Markdown file linking to each URL on its own line and
+// returns its path
+func writeDoc(t *testing.T, dir, name string, urls ...string) string {
+	t.Helper()
+	var b strings.Builder
+	for i, u := range urls {
+		fmt.Fprintf(&b, "[link %d](%s)\n\n", i, u)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// runURLCheck checks files and fails the test on errors
+func runURLCheck(t *testing.T, c *URLChecker, opts CheckOptions) *models.Result {
+	t.Helper()
+	result, err := c.Check(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	return result
+}
+
+// issuesByURL maps the issues of a result to the URLs they report
+func issuesByURL(result *models.Result) map[string]models.Issue {
+	issues := make(map[string]models.Issue)
+	for _, issue := range result.Issues {
+		issues[issue.Context] = issue
+	}
+	return issues
+}
+
+func TestURLCheckerStatuses(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/limited", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		path     string
+		rule     string
+		severity string
+	}{
+		{"/ok", "", ""},
+		{"/get-only", "", ""},
+		{"/moved", RuleURLRedirect, "info"},
+		{"/missing", RuleURLClientError, "error"},
+		{"/broken", RuleURLServerError, "error"},
+		{"/limited", RuleURLRateLimited, "warning"},
+	}
+
+	var urls []string
+	for _, tt := range tests {
+		urls = append(urls, srv.URL+tt.path)
+	}
+	file := writeDoc(t, t.TempDir(), "doc.md", urls...)
+
+	c := newTestURLChecker(srv, 0, time.Second, 0)
+	result := runURLCheck(t, c, CheckOptions{Files: []string{file}})
+	issues := issuesByURL(result)
+
+	for _, tt := range tests {
+		issue, ok := issues[srv.URL+tt.path]
+		if tt.rule == "" {
+			if ok {
+				t.Errorf("%s: unexpected issue %s: %s", tt.path, issue.Rule, issue.Message)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("%s: no issue, want %s", tt.path, tt.rule)
+			continue
+		}
+		if issue.Rule != tt.rule || issue.Severity != tt.severity {
+			t.Errorf("%s: got %s (%s), want %s (%s)", tt.path, issue.Rule, issue.Severity, tt.rule, tt.severity)
+		}
+		if issue.File != file || issue.Line == 0 {
+			t.Errorf("%s: reported at %s:%d", tt.path, issue.File, issue.Line)
+		}
+	}
+
+	if moved := issues[srv.URL+"/moved"]; !strings.Contains(moved.Message, srv.URL+"/ok") {
+		t.Errorf("redirect message %q does not name the final URL", moved.Message)
+	}
+	if result.Summary.TotalIssues != 4 {
+		t.Errorf("TotalIssues = %d, want 4", result.Summary.TotalIssues)
+	}
+}
+
+func TestURLCheckerTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	file := writeDoc(t, t.TempDir(), "doc.md", srv.URL+"/slow")
+	c := newTestURLChecker(srv, 0, 50*time.Millisecond, 0)
+	result := runURLCheck(t, c, CheckOptions{Files: []string{file}})
+
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RuleURLTimeout {
+		t.Fatalf("issues = %+v, want one %s", result.Issues, RuleURLTimeout)
+	}
+	if result.Issues[0].Severity != "warning" {
+		t.Errorf("severity = %s, want warning", result.Issues[0].Severity)
+	}
+}
+
+func TestURLCheckerUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	unreachable := srv.URL + "/gone"
+	c := newTestURLChecker(srv, 0, time.Second, 0)
+	srv.Close()
+
+	file := writeDoc(t, t.TempDir(), "doc.md", unreachable)
+	result := runURLCheck(t, c, CheckOptions{Files: []string{file}})
+
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RuleURLUnreachable {
+		t.Fatalf("issues = %+v, want one %s", result.Issues, RuleURLUnreachable)
+	}
+}
+
+func TestURLCheckerRetriesServerErrors(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		// The first attempt sends HEAD and then GET
+		if requests <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	file := writeDoc(t, t.TempDir(), "doc.md", srv.URL+"/flaky")
+
+	c := newTestURLChecker(srv, 1, time.Second, 0)
+	result := runURLCheck(t, c, CheckOptions{Files: []string{file}})
+	if len(result.Issues) != 0 {
+		t.Errorf("issues = %+v, want none after a retry", result.Issues)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestURLCheckerHostDelay(t *testing.T) {
+	const delay = 50 * time.Millisecond
+
+	var mu sync.Mutex
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	var urls []string
+	for i := 0; i < 4; i++ {
+		urls = append(urls, fmt.Sprintf("%s/page-%d", srv.URL, i))
+	}
+	file := writeDoc(t, t.TempDir(), "doc.md", urls...)
+
+	c := newTestURLChecker(srv, 0, time.Second, delay)
+	runURLCheck(t, c, CheckOptions{Files: []string{file}})
+
+	if len(times) != len(urls) {
+		t.Fatalf("requests = %d, want %d", len(times), len(urls))
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	for i := 1; i < len(times); i++ {
+		// Allow for the scheduling of the workers
+		if gap := times[i].Sub(times[i-1]); gap < delay-10*time.Millisecond {
+			t.Errorf("requests %d and %d were %v apart, want at least %v", i-1, i, gap, delay)
+		}
+	}
+}
+
+func TestURLCheckerSharesURLsAcrossFiles(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	first := writeDoc(t, dir, "first.md", srv.URL+"/missing")
+	second := writeDoc(t, dir, "second.md", srv.URL+"/missing")
+	empty := writeDoc(t, dir, "empty.md")
+
+	var events []ProgressEvent
+	c := newTestURLChecker(srv, 0, time.Second, 0)
+	result := runURLCheck(t, c, CheckOptions{
+		Files: []string{first, second, empty},
+		Progress: func(event ProgressEvent) {
+			mu.Lock()
+			events = append(events, event)
+			mu.Unlock()
+		},
+	})
+
+	// HEAD fails with 404, so GET is tried once as well
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 for one URL", requests)
+	}
+	if len(result.Issues) != 2 {
+		t.Errorf("issues = %d, want one per file", len(result.Issues))
+	}
+
+	reported := make(map[string]int)
+	for _, event := range events {
+		if event.Kind == ProgressFile {
+			reported[event.File] = event.Issues
+		}
+	}
+	want := map[string]int{first: 1, second: 1, empty: 0}
+	if len(reported) != len(want) {
+		t.Errorf("progress reported files %v, want %v", reported, want)
+	}
+	for file, issues := range want {
+		if got, ok := reported[file]; !ok || got != issues {
+			t.Errorf("progress for %s: %d issues (reported %v), want %d", file, got, ok, issues)
+		}
+	}
+}
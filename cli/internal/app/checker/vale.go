@@ -1,10 +1,14 @@
 package checker
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/svx/marvin/cli/internal/pkg/models"
@@ -23,14 +27,77 @@ type ValeOutput map[string][]ValeAlert
 
 // ValeAlert represents a single Vale alert
 type ValeAlert struct {
-	Check       string `json:"Check"`
-	Description string `json:"Description"`
-	Line        int    `json:"Line"`
-	Link        string `json:"Link"`
-	Message     string `json:"Message"`
-	Severity    string `json:"Severity"`
-	Span        []int  `json:"Span"`
-	Match       string `json:"Match"`
+	Check       string     `json:"Check"`
+	Description string     `json:"Description"`
+	Line        int        `json:"Line"`
+	Link        string     `json:"Link"`
+	Message     string     `json:"Message"`
+	Severity    string     `json:"Severity"`
+	Span        []int      `json:"Span"`
+	Match       string     `json:"Match"`
+	Action      ValeAction `json:"Action"`
+}
+
+// ValeAction is the fix Vale suggests for an alert
+type ValeAction struct {
+	Name   string   `json:"Name"`
+	Params []string `json:"Params"`
+}
+
+func init() {
+	Register(Definition{
+		Name:  "vale",
+		Short: "Run Vale prose linting on documentation",
+		Long: `Run Vale prose linting on documentation files.
+
+Vale checks your documentation for style guide violations, grammar issues,
+and other prose problems. It outputs results in an interactive TUI by default,
+or can output JSON for integration with other tools.
+
+By default, Vale scans the docs/ directory. You can specify a different path
+as an argument.`,
+		Example: `  # Scan default docs/ directory with TUI
+  marvin vale
+
+  # Scan specific directory
+  marvin vale ./content
+
+  # Output JSON only
+  marvin vale --json
+
+  # Disable TUI, show plain text
+  marvin vale --no-tui
+
+  # Use custom Vale config
+  marvin vale --config .vale.ini
+
+  # Ignore specific directories
+  marvin vale --glob='!node_modules'
+
+  # Ignore multiple patterns
+  marvin vale --glob='!{node_modules/*,.vitepress/*}'`,
+		Tool:        "vale",
+		Extensions:  []string{".md", ".markdown", ".mdx", ".rst", ".adoc", ".asciidoc", ".txt"},
+		ConfigFiles: []string{".vale.ini", "_vale.ini"},
+		Flags: []Flag{
+			{Name: "config", Usage: "Vale config file path (default: auto-detect .vale.ini)"},
+			{Name: "min-alert-level", Usage: "Minimum alert level (suggestion, warning, error)", Default: "suggestion"},
+			{Name: "glob", Usage: "Glob pattern to filter files (e.g., '!node_modules' or '!{dir1/*,dir2/*}')"},
+		},
+		New: func(settings Settings) (Checker, error) {
+			return NewValeChecker(
+				settings.String("config"),
+				settings.String("min-alert-level"),
+				settings.ToolPath,
+				settings.String("glob"),
+			), nil
+		},
+		Cacheable: func(Settings) bool { return true },
+		// Styles and vocabularies change the issues as much as the config
+		ConfigInputs: func(settings Settings) []string {
+			return valeStylesPaths(settings.String("config"))
+		},
+	})
 }
 
 // NewValeChecker creates a new Vale checker
@@ -63,8 +130,28 @@ func (c *ValeChecker) Validate() error {
 	return nil
 }
 
-// Check runs Vale and returns the results
+// Check runs Vale and returns the results. Many targets are checked in
+// several runs, whose output is merged.
 func (c *ValeChecker) Check(ctx context.Context, opts CheckOptions) (*models.Result, error) {
+	valeOutput := make(ValeOutput)
+	for _, targets := range batchTargets(opts.Targets(), maxTargetsLength()) {
+		output, err := c.run(ctx, targets, opts.ExtraArgs)
+		if err != nil {
+			return nil, err
+		}
+		for file, alerts := range output {
+			valeOutput[file] = append(valeOutput[file], alerts...)
+		}
+	}
+
+	// Transform to our Result format
+	result := c.transformResult(valeOutput, opts.Path)
+	
+	return result, nil
+}
+
+// run runs Vale on targets and parses its output
+func (c *ValeChecker) run(ctx context.Context, targets, extraArgs []string) (ValeOutput, error) {
 	// Build command arguments
 	args := []string{"--output=JSON"}
 
@@ -83,11 +170,11 @@ func (c *ValeChecker) Check(ctx context.Context, opts CheckOptions) (*models.Res
 		args = append(args, "--glob="+c.glob)
 	}
 
-	// Add path to check
-	args = append(args, opts.Path)
+	// Add paths to check
+	args = append(args, targets...)
 
 	// Add any extra arguments
-	args = append(args, opts.ExtraArgs...)
+	args = append(args, extraArgs...)
 
 	// Execute Vale
 	cmd := exec.CommandContext(ctx, c.valePath, args...)
@@ -113,10 +200,7 @@ func (c *ValeChecker) Check(ctx context.Context, opts CheckOptions) (*models.Res
 		return nil, fmt.Errorf("failed to parse vale output: %w", err)
 	}
 
-	// Transform to our Result format
-	result := c.transformResult(valeOutput, opts.Path)
-	
-	return result, nil
+	return valeOutput, nil
 }
 
 // transformResult converts Vale output to our unified Result format
@@ -156,12 +240,14 @@ func (c *ValeChecker) transformResult(valeOutput ValeOutput, path string) *model
 				Message:  alert.Message,
 				Rule:     alert.Check,
 				Context:  alert.Match,
+				RuleURL:  alert.Link,
 			}
 
 			// Set column from Span if available
 			if len(alert.Span) >= 1 {
 				issue.Column = alert.Span[0]
 			}
+			issue.Fix = valeFix(alert)
 
 			result.Issues = append(result.Issues, issue)
 			result.Summary.TotalIssues++
@@ -184,9 +270,43 @@ func (c *ValeChecker) transformResult(valeOutput ValeOutput, path string) *model
 	result.Metadata["config_file"] = c.configFile
 	result.Metadata["min_alert_level"] = c.minAlertLevel
 
+	assignFingerprints(result)
+
 	return result
 }
 
+// valeFix turns the replace and remove actions of an alert into a fix.
+// Spans are 1-based and include their end column.
+func valeFix(alert ValeAlert) *models.Fix {
+	if len(alert.Span) < 2 || alert.Line < 1 {
+		return nil
+	}
+	edit := models.Edit{
+		Line:      alert.Line,
+		Column:    alert.Span[0],
+		EndLine:   alert.Line,
+		EndColumn: alert.Span[1] + 1,
+	}
+
+	switch alert.Action.Name {
+	case "replace":
+		if len(alert.Action.Params) == 0 {
+			return nil
+		}
+		edit.NewText = alert.Action.Params[0]
+		return &models.Fix{
+			Description: fmt.Sprintf("Replace %q with %q", alert.Match, edit.NewText),
+			Edits:       []models.Edit{edit},
+		}
+	case "remove":
+		return &models.Fix{
+			Description: fmt.Sprintf("Remove %q", alert.Match),
+			Edits:       []models.Edit{edit},
+		}
+	}
+	return nil
+}
+
 // normalizeSeverity converts Vale severity to our standard format
 func normalizeSeverity(severity string) string {
 	switch severity {
@@ -200,3 +320,39 @@ func normalizeSeverity(severity string) string {
 		return "info"
 	}
 }
+
+// valeStylesPaths returns the StylesPath directory set in a Vale config
+// file, resolved relative to the config file. It returns nil if the file
+// cannot be read or sets no StylesPath.
+func valeStylesPaths(configFile string) []string {
+	if configFile == "" {
+		return nil
+	}
+	file, err := os.Open(configFile)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			// StylesPath is a global setting, before the first section
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "StylesPath") {
+			continue
+		}
+		stylesPath := strings.Trim(strings.TrimSpace(value), `"'`)
+		if stylesPath == "" {
+			return nil
+		}
+		if !filepath.IsAbs(stylesPath) {
+			stylesPath = filepath.Join(filepath.Dir(configFile), stylesPath)
+		}
+		return []string{stylesPath}
+	}
+	return nil
+}
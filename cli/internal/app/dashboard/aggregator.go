@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -32,7 +33,7 @@ func LoadDashboardData(outputDir string) (*models.DashboardData, error) {
 	// Parse all result files
 	var allResults []*models.Result
 	for _, file := range files {
-		result, err := parseResultFile(file)
+		result, err := ParseResultFile(file)
 		if err != nil {
 			// Skip files that can't be parsed
 			continue
@@ -80,8 +81,9 @@ func scanResultsDirectory(dir string) ([]string, error) {
 	return files, nil
 }
 
-// parseResultFile reads and parses a single result JSON file
-func parseResultFile(path string) (*models.Result, error) {
+// ParseResultFile reads and parses a single result JSON file. The result ID
+// is set from the file name.
+func ParseResultFile(path string) (*models.Result, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
@@ -91,10 +93,42 @@ func parseResultFile(path string) (*models.Result, error) {
 	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON from %s: %w", path, err)
 	}
+	result.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
 
 	return &result, nil
 }
 
+// CheckerResultFiles returns the result files saved for a checker in the
+// output directory, newest first. Files are matched by the name the JSON
+// writer gives them: {checker}-{YYYYMMDD-HHMMSS}.json.
+func CheckerResultFiles(outputDir, checker string) ([]string, error) {
+	entries, err := os.ReadDir(outputDir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results directory: %w", err)
+	}
+
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(checker) + `-\d{8}-\d{6}\.json$`)
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && pattern.MatchString(entry.Name()) {
+			files = append(files, filepath.Join(outputDir, entry.Name()))
+		}
+	}
+
+	// The timestamp format sorts lexically
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+
+	return files, nil
+}
+
+// FromResults builds dashboard data from results that are already in memory
+func FromResults(results []*models.Result) *models.DashboardData {
+	return aggregateResults(results)
+}
+
 // aggregateResults processes all results and creates dashboard data
 func aggregateResults(results []*models.Result) *models.DashboardData {
 	// Group results by checker
@@ -30,6 +30,23 @@ func NewMultiDetector() *MultiDetector {
 	}
 }
 
+// NewMultiDetectorWith creates a multi-source detector limited to the enabled sources
+func NewMultiDetectorWith(brew, npm, system bool) *MultiDetector {
+	var detectors []Detector
+	if brew {
+		detectors = append(detectors, &BrewDetector{})
+	}
+	if npm {
+		detectors = append(detectors, &NpmDetector{})
+	}
+	if system {
+		detectors = append(detectors, &SystemDetector{})
+	}
+	return &MultiDetector{
+		detectors: detectors,
+	}
+}
+
 // IsInstalled checks if a tool is installed using multiple detection methods
 func (d *MultiDetector) IsInstalled(tool string) (bool, string, error) {
 	// Special handling for markdownlint - try multiple variants
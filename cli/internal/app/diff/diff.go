@@ -0,0 +1,228 @@
+package diff
+
+import (
+	"sort"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// Status classifies an issue when comparing two results
+type Status string
+
+const (
+	// StatusNew marks an issue that is only in the new result
+	StatusNew Status = "new"
+
+	// StatusFixed marks an issue that is only in the old result
+	StatusFixed Status = "fixed"
+
+	// StatusUnchanged marks an issue that is in both results
+	StatusUnchanged Status = "unchanged"
+)
+
+// Report is the comparison of two results of the same checker
+type Report struct {
+	Checker string     `json:"checker"`
+	Old     ResultInfo `json:"old"`
+	New     ResultInfo `json:"new"`
+	Summary Summary    `json:"summary"`
+	Rules   []Delta    `json:"rules"`
+	Files   []Delta    `json:"files"`
+	Issues  []Change   `json:"issues"`
+}
+
+// ResultInfo describes one side of the comparison
+type ResultInfo struct {
+	File      string         `json:"file,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+	Path      string         `json:"path"`
+	Summary   models.Summary `json:"summary"`
+}
+
+// Summary counts the issues by status
+type Summary struct {
+	New       int `json:"new"`
+	Fixed     int `json:"fixed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Delta is the change in issue counts for a rule or a file
+type Delta struct {
+	Name      string `json:"name"`
+	Old       int    `json:"old"`
+	New       int    `json:"new"`
+	Added     int    `json:"added"`
+	Fixed     int    `json:"fixed"`
+	Unchanged int    `json:"unchanged"`
+}
+
+// Change is an issue together with its status. Fixed issues come from the
+// old result, new and unchanged issues from the new result.
+type Change struct {
+	Status Status `json:"status"`
+	models.Issue
+}
+
+// Compare classifies the issues of two results as new, fixed or unchanged.
+//
+// Issues are matched by fingerprint. Issues without a fingerprint, such as
+// those in results saved by older versions of Marvin, are matched by file,
+// rule and context text instead.
+func Compare(oldResult, newResult *models.Result, oldFile, newFile string) *Report {
+	report := &Report{
+		Checker: newResult.Checker,
+		Old:     resultInfo(oldResult, oldFile),
+		New:     resultInfo(newResult, newFile),
+		Issues:  []Change{},
+	}
+
+	oldMatched := make([]bool, len(oldResult.Issues))
+	newMatched := make([]bool, len(newResult.Issues))
+
+	// 1. Match by fingerprint
+	byFingerprint := make(map[string][]int)
+	for i, issue := range oldResult.Issues {
+		if issue.Fingerprint != "" {
+			byFingerprint[issue.Fingerprint] = append(byFingerprint[issue.Fingerprint], i)
+		}
+	}
+	for i, issue := range newResult.Issues {
+		candidates := byFingerprint[issue.Fingerprint]
+		if issue.Fingerprint == "" || len(candidates) == 0 {
+			continue
+		}
+		oldMatched[candidates[0]] = true
+		newMatched[i] = true
+		byFingerprint[issue.Fingerprint] = candidates[1:]
+	}
+
+	// 2. Match the rest by file, rule and context
+	byKey := make(map[models.IssueKey][]int)
+	for i, issue := range oldResult.Issues {
+		if !oldMatched[i] {
+			k := issue.Key()
+			byKey[k] = append(byKey[k], i)
+		}
+	}
+	for i, issue := range newResult.Issues {
+		if newMatched[i] {
+			continue
+		}
+		k := issue.Key()
+		candidates := byKey[k]
+		if len(candidates) == 0 {
+			continue
+		}
+		oldMatched[candidates[0]] = true
+		newMatched[i] = true
+		byKey[k] = candidates[1:]
+	}
+
+	// 3. Classify
+	for i, issue := range newResult.Issues {
+		status := StatusNew
+		if newMatched[i] {
+			status = StatusUnchanged
+		}
+		report.Issues = append(report.Issues, Change{Status: status, Issue: issue})
+	}
+	for i, issue := range oldResult.Issues {
+		if !oldMatched[i] {
+			report.Issues = append(report.Issues, Change{Status: StatusFixed, Issue: issue})
+		}
+	}
+	sortChanges(report.Issues)
+
+	// 4. Summarize
+	rules := make(map[string]*Delta)
+	files := make(map[string]*Delta)
+	for _, change := range report.Issues {
+		switch change.Status {
+		case StatusNew:
+			report.Summary.New++
+		case StatusFixed:
+			report.Summary.Fixed++
+		case StatusUnchanged:
+			report.Summary.Unchanged++
+		}
+		addToDelta(rules, change.Rule, change.Status)
+		addToDelta(files, models.NormalizePath(change.File), change.Status)
+	}
+	report.Rules = sortedDeltas(rules)
+	report.Files = sortedDeltas(files)
+
+	return report
+}
+
+// HasChanges reports whether any issue was added or fixed
+func (r *Report) HasChanges() bool {
+	return r.Summary.New > 0 || r.Summary.Fixed > 0
+}
+
+// resultInfo describes a result for the report
+func resultInfo(result *models.Result, file string) ResultInfo {
+	return ResultInfo{
+		File:      file,
+		Timestamp: result.Timestamp,
+		Path:      result.Path,
+		Summary:   result.Summary,
+	}
+}
+
+// addToDelta counts a change towards the delta of name
+func addToDelta(deltas map[string]*Delta, name string, status Status) {
+	delta, ok := deltas[name]
+	if !ok {
+		delta = &Delta{Name: name}
+		deltas[name] = delta
+	}
+
+	switch status {
+	case StatusNew:
+		delta.Added++
+		delta.New++
+	case StatusFixed:
+		delta.Fixed++
+		delta.Old++
+	case StatusUnchanged:
+		delta.Unchanged++
+		delta.Old++
+		delta.New++
+	}
+}
+
+// sortedDeltas returns the deltas with the biggest changes first
+func sortedDeltas(deltas map[string]*Delta) []Delta {
+	sorted := make([]Delta, 0, len(deltas))
+	for _, delta := range deltas {
+		sorted = append(sorted, *delta)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		ci := sorted[i].Added + sorted[i].Fixed
+		cj := sorted[j].Added + sorted[j].Fixed
+		if ci != cj {
+			return ci > cj
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// sortChanges orders changes by status, then by location
+func sortChanges(changes []Change) {
+	order := map[Status]int{StatusNew: 0, StatusFixed: 1, StatusUnchanged: 2}
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Status != b.Status {
+			return order[a.Status] < order[b.Status]
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
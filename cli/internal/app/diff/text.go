@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+)
+
+// statusSymbols prefixes issues by status in plain text output
+var statusSymbols = map[Status]string{
+	StatusNew:       "+",
+	StatusFixed:     "-",
+	StatusUnchanged: " ",
+}
+
+// WriteText writes the report as plain text. Unchanged issues are only
+// counted unless showUnchanged is set.
+func (r *Report) WriteText(w io.Writer, showUnchanged bool) error {
+	// Header
+	fmt.Fprintf(w, "Marvin - %s Diff\n", r.Checker)
+	fmt.Fprintf(w, "═══════════════════════════════════════════════════════════\n\n")
+
+	// Summary
+	fmt.Fprintf(w, "Summary:\n")
+	fmt.Fprintf(w, "  Old: %s (%s, %d issues)\n", describe(r.Old), r.Old.Timestamp.Format("2006-01-02 15:04:05"), r.Old.Summary.TotalIssues)
+	fmt.Fprintf(w, "  New: %s (%s, %d issues)\n", describe(r.New), r.New.Timestamp.Format("2006-01-02 15:04:05"), r.New.Summary.TotalIssues)
+	fmt.Fprintf(w, "  New Issues: %d\n", r.Summary.New)
+	fmt.Fprintf(w, "  Fixed Issues: %d\n", r.Summary.Fixed)
+	fmt.Fprintf(w, "  Unchanged Issues: %d\n\n", r.Summary.Unchanged)
+
+	if !r.HasChanges() {
+		fmt.Fprintf(w, "No changes.\n\n")
+		return nil
+	}
+
+	// Deltas
+	writeDeltas(w, "By Rule", r.Rules)
+	writeDeltas(w, "By File", r.Files)
+
+	// Issues
+	fmt.Fprintf(w, "Issues:\n")
+	fmt.Fprintf(w, "───────────────────────────────────────────────────────────\n\n")
+	for _, change := range r.Issues {
+		if change.Status == StatusUnchanged && !showUnchanged {
+			continue
+		}
+		fmt.Fprintf(w, "%s %s:%d:%d\n", statusSymbols[change.Status], change.File, change.Line, change.Column)
+		fmt.Fprintf(w, "  [%s] %s (%s)\n", change.Severity, change.Rule, change.Status)
+		fmt.Fprintf(w, "  %s\n\n", change.Message)
+	}
+
+	return nil
+}
+
+// writeDeltas writes the rules or files whose issue count changed
+func writeDeltas(w io.Writer, title string, deltas []Delta) {
+	fmt.Fprintf(w, "%s:\n", title)
+	for _, delta := range deltas {
+		if delta.Added == 0 && delta.Fixed == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  %-40s %4d → %-4d (+%d, -%d)\n", delta.Name, delta.Old, delta.New, delta.Added, delta.Fixed)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// describe names one side of the comparison
+func describe(info ResultInfo) string {
+	if info.File != "" {
+		return info.File
+	}
+	return info.Path
+}
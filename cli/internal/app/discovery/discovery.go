@@ -0,0 +1,101 @@
+package discovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/app/ignore"
+)
+
+// Options control which files are discovered
+type Options struct {
+	// Extensions are the file extensions to include, such as ".md"
+	Extensions []string
+
+	// Ignore excludes files matched by ignore files, nil to include all files
+	Ignore *ignore.Matcher
+}
+
+// Files walks paths and returns the files with one of the extensions that
+// are not ignored, without duplicates. Files passed directly in paths are
+// always included. Hidden directories and node_modules are skipped, as are
+// ignored directories.
+func Files(paths []string, opts Options) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+		if !info.IsDir() {
+			add(root)
+			continue
+		}
+
+		if opts.Ignore != nil {
+			if err := opts.Ignore.LoadParents(root); err != nil {
+				return nil, err
+			}
+		}
+
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() {
+				if path == root {
+					return nil
+				}
+				name := info.Name()
+				if strings.HasPrefix(name, ".") || name == "node_modules" {
+					return filepath.SkipDir
+				}
+				if opts.Ignore != nil {
+					if opts.Ignore.Ignored(path, true) {
+						return filepath.SkipDir
+					}
+					return opts.Ignore.LoadDir(path)
+				}
+				return nil
+			}
+
+			if !HasExtension(path, opts.Extensions) {
+				return nil
+			}
+			if opts.Ignore != nil && opts.Ignore.Ignored(path, false) {
+				return nil
+			}
+			add(path)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	return files, nil
+}
+
+// HasExtension reports whether path ends in one of the extensions. The
+// comparison is case-insensitive.
+func HasExtension(path string, extensions []string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range extensions {
+		if strings.HasSuffix(lower, strings.ToLower(ext)) {
+			return true
+		}
+	}
+	return false
+}
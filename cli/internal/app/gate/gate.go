@@ -0,0 +1,220 @@
+package gate
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// Severity levels a policy can fail on, from most to least severe
+const (
+	FailOnError   = "error"
+	FailOnWarning = "warning"
+	FailOnInfo    = "info"
+	FailOnNone    = "none"
+)
+
+// Policy describes when a check fails. Only issues that are not in the
+// baseline count towards the limits.
+type Policy struct {
+	// FailOn is the lowest severity that fails the check: error, warning,
+	// info or none
+	FailOn string
+
+	// MaxErrors and MaxWarnings are budgets that replace the zero tolerance
+	// of FailOn for their severity. Nil means no budget.
+	MaxErrors   *int
+	MaxWarnings *int
+
+	// Rules limits the number of issues per rule ID
+	Rules map[string]int
+
+	// Paths limits the number of issues per file or directory
+	Paths map[string]int
+}
+
+// Check is the outcome of a single gate
+type Check struct {
+	Name   string `json:"name"`
+	Limit  int    `json:"limit"`
+	Actual int    `json:"actual"`
+	Passed bool   `json:"passed"`
+}
+
+// Over returns by how much the limit was exceeded
+func (c Check) Over() int {
+	if c.Actual <= c.Limit {
+		return 0
+	}
+	return c.Actual - c.Limit
+}
+
+// Report is the outcome of evaluating a policy
+type Report struct {
+	Passed bool    `json:"passed"`
+	Checks []Check `json:"checks"`
+}
+
+// Validate checks that the policy is well-formed
+func (p Policy) Validate() error {
+	switch p.FailOn {
+	case "", FailOnError, FailOnWarning, FailOnInfo, FailOnNone:
+	default:
+		return fmt.Errorf("invalid fail-on level %q (use error, warning, info or none)", p.FailOn)
+	}
+	if p.MaxErrors != nil && *p.MaxErrors < 0 {
+		return fmt.Errorf("max errors must not be negative")
+	}
+	if p.MaxWarnings != nil && *p.MaxWarnings < 0 {
+		return fmt.Errorf("max warnings must not be negative")
+	}
+	for rule, limit := range p.Rules {
+		if limit < 0 {
+			return fmt.Errorf("limit for rule %s must not be negative", rule)
+		}
+	}
+	for path, limit := range p.Paths {
+		if limit < 0 {
+			return fmt.Errorf("limit for path %s must not be negative", path)
+		}
+	}
+	return nil
+}
+
+// Evaluate applies the policy to the results of one or more checkers
+func (p Policy) Evaluate(results []*models.Result) *Report {
+	failOn := p.FailOn
+	if failOn == "" {
+		failOn = FailOnError
+	}
+
+	// 1. Count new issues
+	counts := make(map[string]int)
+	rules := make(map[string]int)
+	paths := make(map[string]int)
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			if issue.Baselined {
+				continue
+			}
+			counts[severityLevel(issue.Severity)]++
+			rules[issue.Rule]++
+			file := filepath.ToSlash(filepath.Clean(issue.File))
+			for path := range p.Paths {
+				if matchesPath(file, path) {
+					paths[path]++
+				}
+			}
+		}
+	}
+
+	report := &Report{Passed: true, Checks: []Check{}}
+	add := func(name string, limit, actual int) {
+		check := Check{Name: name, Limit: limit, Actual: actual, Passed: actual <= limit}
+		report.Checks = append(report.Checks, check)
+		if !check.Passed {
+			report.Passed = false
+		}
+	}
+
+	// 2. Severity gates
+	switch {
+	case p.MaxErrors != nil:
+		add("max errors", *p.MaxErrors, counts[FailOnError])
+	case failsOn(failOn, FailOnError):
+		add("fail on error", 0, counts[FailOnError])
+	}
+	switch {
+	case p.MaxWarnings != nil:
+		add("max warnings", *p.MaxWarnings, counts[FailOnWarning])
+	case failsOn(failOn, FailOnWarning):
+		add("fail on warning", 0, counts[FailOnWarning])
+	}
+	if failsOn(failOn, FailOnInfo) {
+		add("fail on info", 0, counts[FailOnInfo])
+	}
+
+	// 3. Rule and path gates
+	for _, rule := range sortedKeys(p.Rules) {
+		add("rule "+rule, p.Rules[rule], rules[rule])
+	}
+	for _, path := range sortedKeys(p.Paths) {
+		add("path "+path, p.Paths[path], paths[path])
+	}
+
+	return report
+}
+
+// Write writes the gate report as plain text
+func (r *Report) Write(w io.Writer) {
+	if len(r.Checks) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Quality Gate: ")
+	if r.Passed {
+		fmt.Fprintf(w, "passed\n")
+	} else {
+		fmt.Fprintf(w, "failed\n")
+	}
+
+	for _, check := range r.Checks {
+		status := "✓"
+		detail := fmt.Sprintf("%d of %d allowed", check.Actual, check.Limit)
+		if !check.Passed {
+			status = "✗"
+			detail += fmt.Sprintf(", %d over", check.Over())
+		}
+		fmt.Fprintf(w, "  %s %-30s %s\n", status, check.Name, detail)
+	}
+}
+
+// failsOn reports whether a fail-on level includes severity
+func failsOn(failOn, severity string) bool {
+	return rank(severity) >= rank(failOn)
+}
+
+// rank orders levels by severity; none ranks above all severities
+func rank(level string) int {
+	switch level {
+	case FailOnNone:
+		return 3
+	case FailOnError:
+		return 2
+	case FailOnWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// severityLevel maps an issue severity to a gate level
+func severityLevel(severity string) string {
+	switch severity {
+	case "error":
+		return FailOnError
+	case "warning":
+		return FailOnWarning
+	default:
+		return FailOnInfo
+	}
+}
+
+// matchesPath reports whether file is path or inside the directory path
+func matchesPath(file, path string) bool {
+	path = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(path)), "/")
+	return file == path || strings.HasPrefix(file, path+"/")
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
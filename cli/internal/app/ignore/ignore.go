@@ -0,0 +1,297 @@
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// File is the name of Marvin's ignore file
+const File = ".marvinignore"
+
+// GitFile is the name of git's ignore file
+const GitFile = ".gitignore"
+
+// pattern is a compiled gitignore pattern
+type pattern struct {
+	// base is the absolute, slash-separated directory the pattern is
+	// relative to: the directory of the file it was read from
+	base    string
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Matcher decides which paths are ignored using gitignore semantics.
+// Patterns from ignore files in subdirectories apply relative to their
+// directory, and later patterns take precedence over earlier ones.
+// A Matcher is safe for concurrent use.
+type Matcher struct {
+	mu       sync.Mutex
+	names    []string
+	patterns []pattern
+	loaded   map[string]bool
+}
+
+// New creates a matcher that reads ignore files with the given names, such
+// as .marvinignore and .gitignore
+func New(names ...string) *Matcher {
+	return &Matcher{
+		names:  names,
+		loaded: make(map[string]bool),
+	}
+}
+
+// LoadDir reads the ignore files in dir, if any. Each directory is only
+// read once.
+func (m *Matcher) LoadDir(dir string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.loadDir(dir)
+}
+
+func (m *Matcher) loadDir(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if m.loaded[abs] {
+		return nil
+	}
+	m.loaded[abs] = true
+
+	for _, name := range m.names {
+		if err := m.addFile(filepath.Join(abs, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addFile reads the patterns of an ignore file. A missing file is ignored.
+func (m *Matcher) addFile(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return m.addPatterns(filepath.Dir(path), lines)
+}
+
+// addPatterns adds gitignore patterns relative to the directory base
+func (m *Matcher) addPatterns(base string, lines []string) error {
+	abs, err := filepath.Abs(base)
+	if err != nil {
+		return err
+	}
+	abs = filepath.ToSlash(abs)
+
+	for _, line := range lines {
+		p, ok := parsePattern(line)
+		if !ok {
+			continue
+		}
+		p.base = abs
+		m.patterns = append(m.patterns, p)
+	}
+	return nil
+}
+
+// Ignored reports whether path is ignored, either itself or because one of
+// its parent directories is
+func (m *Matcher) Ignored(path string, isDir bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ignored(path, isDir)
+}
+
+func (m *Matcher) ignored(path string, isDir bool) bool {
+	if len(m.patterns) == 0 {
+		return false
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	abs = filepath.ToSlash(abs)
+
+	// Check parent directories from the top down
+	for i := 1; i < len(abs); i++ {
+		if abs[i] == '/' && m.match(abs[:i], true) {
+			return true
+		}
+	}
+	return m.match(abs, isDir)
+}
+
+// match reports whether the patterns ignore an absolute, slash-separated
+// path, without looking at its parents
+func (m *Matcher) match(path string, isDir bool) bool {
+	ignored := false
+	for _, p := range m.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if !strings.HasPrefix(path, p.base+"/") {
+			continue
+		}
+		if p.re.MatchString(path[len(p.base)+1:]) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+// LoadParents loads the ignore files of the working directory and of every
+// directory between it and path
+func (m *Matcher) LoadParents(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	dir := abs
+	if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+		dir = filepath.Dir(abs)
+	}
+	rel, err := filepath.Rel(wd, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return m.loadDir(dir)
+	}
+
+	current := wd
+	if err := m.loadDir(current); err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		if err := m.loadDir(current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePattern parses one line of an ignore file. It returns false for
+// blank lines and comments.
+func parsePattern(line string) (pattern, bool) {
+	var p pattern
+
+	// Trailing spaces are ignored unless escaped
+	if !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return p, false
+	}
+
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return p, false
+	}
+
+	// A pattern with a slash other than at the end is relative to the
+	// directory of the ignore file; otherwise it matches at any depth
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return p, false
+	}
+	p.re = re
+	return p, true
+}
+
+// globToRegexp converts a gitignore glob to a regular expression
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				atStart := i == 0 || glob[i-1] == '/'
+				atEnd := i+2 == len(glob) || glob[i+2] == '/'
+				if atStart && atEnd {
+					i++
+					switch {
+					case i+1 == len(glob):
+						// Trailing "/**" matches everything inside
+						b.WriteString(".*")
+					default:
+						// Leading "**/" and inner "/**/" match zero or more directories
+						b.WriteString("(?:.*/)?")
+						i++
+					}
+					continue
+				}
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,596 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// DefaultDelay is how long the server waits after the last change to a
+// document before checking it
+const DefaultDelay = 500 * time.Millisecond
+
+// LintFunc checks the content of a document, which may differ from the file
+// on disk, and returns the results of all checkers
+type LintFunc func(ctx context.Context, path string, content []byte) ([]*models.Result, error)
+
+// Server is a language server that publishes the issues found by Marvin's
+// checkers as diagnostics and offers their fixes as code actions
+type Server struct {
+	lint  LintFunc
+	delay time.Duration
+
+	out     io.Writer
+	writeMu sync.Mutex
+
+	// lintMu runs one check at a time, as checkers are not safe for
+	// concurrent use
+	lintMu sync.Mutex
+
+	mu          sync.Mutex
+	docs        map[string]*document
+	initialized bool
+	shutdown    bool
+}
+
+// document is an open text document
+type document struct {
+	uri     string
+	path    string
+	version int
+	text    string
+
+	// issues were found in linted, the text of the last check
+	issues []sourcedIssue
+	linted string
+
+	timer  *time.Timer
+	cancel context.CancelFunc
+}
+
+// sourcedIssue is an issue together with the checker that reported it
+type sourcedIssue struct {
+	checker string
+	models.Issue
+}
+
+// NewServer creates a language server that checks documents with lint
+// once they have not changed for delay
+func NewServer(lint LintFunc, delay time.Duration) *Server {
+	return &Server{
+		lint:  lint,
+		delay: delay,
+		docs:  make(map[string]*document),
+	}
+}
+
+// Run serves requests read from in and writes responses and notifications
+// to out until the client sends the exit notification or closes in
+func (s *Server) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		body, err := readMessage(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) && s.isShutdown() {
+				return nil
+			}
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			s.replyError(nil, codeParseError, fmt.Sprintf("invalid message: %v", err))
+			continue
+		}
+
+		if msg.Method == "exit" {
+			if !s.isShutdown() {
+				return fmt.Errorf("exit without shutdown request")
+			}
+			return nil
+		}
+		s.handle(ctx, &msg)
+	}
+}
+
+// handle dispatches a request or notification
+func (s *Server) handle(ctx context.Context, msg *message) {
+	isRequest := msg.ID != nil
+
+	s.mu.Lock()
+	initialized := s.initialized
+	s.mu.Unlock()
+	if !initialized && msg.Method != "initialize" {
+		if isRequest {
+			s.replyError(msg.ID, codeServerNotInitialized, "server not initialized")
+		}
+		return
+	}
+
+	switch msg.Method {
+	case "initialize":
+		s.mu.Lock()
+		s.initialized = true
+		s.mu.Unlock()
+		s.reply(msg.ID, initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: textDocumentSyncOptions{
+					OpenClose: true,
+					Change:    syncFull,
+					Save:      saveOptions{IncludeText: true},
+				},
+				CodeActionProvider: codeActionOptions{CodeActionKinds: []string{"quickfix"}},
+			},
+			ServerInfo: serverInfo{Name: "marvin"},
+		})
+
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		// Nothing to do
+
+	case "shutdown":
+		s.mu.Lock()
+		s.shutdown = true
+		for _, doc := range s.docs {
+			doc.stop()
+		}
+		s.mu.Unlock()
+		s.reply(msg.ID, nil)
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if s.decode(msg, &params) {
+			s.open(ctx, params.TextDocument)
+		}
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if s.decode(msg, &params) && len(params.ContentChanges) > 0 {
+			// Only full document sync is offered, so the last change holds
+			// the whole text
+			text := params.ContentChanges[len(params.ContentChanges)-1].Text
+			s.change(ctx, params.TextDocument.URI, params.TextDocument.Version, text, s.delay)
+		}
+
+	case "textDocument/didSave":
+		var params didSaveParams
+		if s.decode(msg, &params) {
+			s.save(ctx, params.TextDocument.URI, params.Text)
+		}
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if s.decode(msg, &params) {
+			s.close(params.TextDocument.URI)
+		}
+
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if s.decode(msg, &params) {
+			s.reply(msg.ID, s.codeActions(params))
+		}
+
+	default:
+		if isRequest {
+			s.replyError(msg.ID, codeMethodNotFound, "method not found: "+msg.Method)
+		}
+	}
+}
+
+// decode unmarshals the params of a message, replying with an error to
+// requests with invalid params
+func (s *Server) decode(msg *message, params interface{}) bool {
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		if msg.ID != nil {
+			s.replyError(msg.ID, codeInvalidParams, fmt.Sprintf("invalid params: %v", err))
+		}
+		return false
+	}
+	return true
+}
+
+// open starts tracking a document and checks it
+func (s *Server) open(ctx context.Context, item textDocumentItem) {
+	path, err := uriToPath(item.URI)
+	if err != nil {
+		s.logMessage(messageError, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	if doc, ok := s.docs[item.URI]; ok {
+		doc.stop()
+	}
+	s.docs[item.URI] = &document{
+		uri:     item.URI,
+		path:    path,
+		version: item.Version,
+		text:    item.Text,
+	}
+	s.mu.Unlock()
+
+	s.schedule(ctx, item.URI, 0)
+}
+
+// change updates the text of a document and checks it after delay
+func (s *Server) change(ctx context.Context, uri string, version int, text string, delay time.Duration) {
+	s.mu.Lock()
+	doc, ok := s.docs[uri]
+	if ok {
+		doc.version = version
+		doc.text = text
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.schedule(ctx, uri, delay)
+	}
+}
+
+// save checks a document right away, with the saved text if the client
+// sent it
+func (s *Server) save(ctx context.Context, uri string, text *string) {
+	s.mu.Lock()
+	doc, ok := s.docs[uri]
+	if ok && text != nil {
+		doc.text = *text
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.schedule(ctx, uri, 0)
+	}
+}
+
+// close stops tracking a document and clears its diagnostics
+func (s *Server) close(uri string) {
+	s.mu.Lock()
+	doc, ok := s.docs[uri]
+	if ok {
+		doc.stop()
+		delete(s.docs, uri)
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         uri,
+			Diagnostics: []diagnostic{},
+		})
+	}
+}
+
+// schedule checks a document after delay, replacing a pending or running
+// check of an older version
+func (s *Server) schedule(ctx context.Context, uri string, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, ok := s.docs[uri]
+	if !ok || s.shutdown {
+		return
+	}
+	doc.stop()
+
+	runCtx, cancel := context.WithCancel(ctx)
+	doc.cancel = cancel
+	doc.timer = time.AfterFunc(delay, func() {
+		s.check(runCtx, uri)
+	})
+}
+
+// stop cancels a pending or running check
+func (d *document) stop() {
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	if d.cancel != nil {
+		d.cancel()
+	}
+}
+
+// check lints the current text of a document and publishes its diagnostics
+func (s *Server) check(ctx context.Context, uri string) {
+	s.lintMu.Lock()
+	defer s.lintMu.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.mu.Lock()
+	doc, ok := s.docs[uri]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	path, text, version := doc.path, doc.text, doc.version
+	s.mu.Unlock()
+
+	results, err := s.lint(ctx, path, []byte(text))
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		s.logMessage(messageError, fmt.Sprintf("marvin: %s: %v", path, err))
+	}
+
+	var issues []sourcedIssue
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			issues = append(issues, sourcedIssue{checker: result.Checker, Issue: issue})
+		}
+	}
+
+	// Drop the results if the document changed or closed in the meantime
+	s.mu.Lock()
+	doc, ok = s.docs[uri]
+	if !ok || doc.version != version || doc.text != text {
+		s.mu.Unlock()
+		return
+	}
+	doc.issues = issues
+	doc.linted = text
+	s.mu.Unlock()
+
+	lines := splitLines(text)
+	diagnostics := make([]diagnostic, 0, len(issues))
+	for _, issue := range issues {
+		diagnostics = append(diagnostics, toDiagnostic(issue, lines))
+	}
+	s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Version:     &version,
+		Diagnostics: diagnostics,
+	})
+}
+
+// codeActions returns the fixes of the issues in a range of a document
+func (s *Server) codeActions(params codeActionParams) []codeAction {
+	actions := []codeAction{}
+
+	s.mu.Lock()
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok || doc.text != doc.linted {
+		// Fixes of outdated issues would edit the wrong text
+		s.mu.Unlock()
+		return actions
+	}
+	issues, text := doc.issues, doc.linted
+	s.mu.Unlock()
+
+	lines := splitLines(text)
+	for _, issue := range issues {
+		if issue.Fix == nil || len(issue.Fix.Edits) == 0 {
+			continue
+		}
+		diag := toDiagnostic(issue, lines)
+		if !overlaps(diag.Range, params.Range) {
+			continue
+		}
+
+		edits := make([]textEdit, 0, len(issue.Fix.Edits))
+		for _, edit := range issue.Fix.Edits {
+			edits = append(edits, textEdit{
+				Range: textRange{
+					Start: toPosition(lines, edit.Line, edit.Column),
+					End:   toPosition(lines, edit.EndLine, edit.EndColumn),
+				},
+				NewText: edit.NewText,
+			})
+		}
+
+		actions = append(actions, codeAction{
+			Title:       issue.Fix.Description,
+			Kind:        "quickfix",
+			Diagnostics: []diagnostic{diag},
+			IsPreferred: true,
+			Edit: workspaceEdit{
+				Changes: map[string][]textEdit{params.TextDocument.URI: edits},
+			},
+		})
+	}
+
+	return actions
+}
+
+// isShutdown reports whether the client requested a shutdown
+func (s *Server) isShutdown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shutdown
+}
+
+// reply sends the result of a request
+func (s *Server) reply(id *json.RawMessage, result interface{}) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		s.replyError(id, codeInvalidRequest, err.Error())
+		return
+	}
+	s.send(&message{ID: id, Result: data})
+}
+
+// replyError sends the error of a request
+func (s *Server) replyError(id *json.RawMessage, code int, text string) {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	s.send(&message{ID: id, Error: &responseError{Code: code, Message: text}})
+}
+
+// notify sends a notification
+func (s *Server) notify(method string, params interface{}) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+	s.send(&message{Method: method, Params: data})
+}
+
+// logMessage shows a message in the client's log
+func (s *Server) logMessage(kind int, text string) {
+	s.notify("window/logMessage", logMessageParams{Type: kind, Message: text})
+}
+
+// send writes a message; messages from concurrent checks are not interleaved
+func (s *Server) send(msg *message) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	writeMessage(s.out, msg)
+}
+
+// toDiagnostic converts an issue into a diagnostic. The range covers the
+// matched context, the word at the issue's column, or the whole line if the
+// issue has no column.
+func toDiagnostic(issue sourcedIssue, lines []string) diagnostic {
+	diag := diagnostic{
+		Severity: toSeverity(issue.Severity),
+		Code:     issue.Rule,
+		Source:   "marvin/" + issue.checker,
+		Message:  issue.Message,
+	}
+	if issue.RuleURL != "" {
+		diag.CodeDescription = &codeDescription{Href: issue.RuleURL}
+	}
+
+	line := issue.Line
+	if line < 1 {
+		line = 1
+	}
+	text := ""
+	if line <= len(lines) {
+		text = lines[line-1]
+	}
+	runes := []rune(text)
+
+	start, end := 0, len(runes)
+	if issue.Column > 0 && issue.Column <= len(runes) {
+		start = issue.Column - 1
+		switch {
+		case issue.Context != "" && strings.HasPrefix(string(runes[start:]), issue.Context):
+			end = start + len([]rune(issue.Context))
+		default:
+			end = start
+			for end < len(runes) && !isSpace(runes[end]) {
+				end++
+			}
+			if end == start {
+				end = len(runes)
+			}
+		}
+	}
+
+	diag.Range = textRange{
+		Start: toPosition(lines, line, start+1),
+		End:   toPosition(lines, line, end+1),
+	}
+	return diag
+}
+
+// toSeverity maps an issue severity to a diagnostic severity. Baselined
+// issues keep their severity.
+func toSeverity(severity string) int {
+	switch severity {
+	case "error":
+		return severityError
+	case "warning":
+		return severityWarning
+	case "info", "suggestion":
+		return severityInformation
+	}
+	return severityHint
+}
+
+// toPosition converts a 1-based line and character column into an LSP
+// position, which counts UTF-16 code units. Positions past the end of the
+// text are clamped to its end.
+func toPosition(lines []string, line, column int) position {
+	if line < 1 {
+		return position{}
+	}
+	if line > len(lines) {
+		last := len(lines) - 1
+		return position{Line: last, Character: utf16Len([]rune(lines[last]))}
+	}
+
+	runes := []rune(lines[line-1])
+	if column < 1 {
+		column = 1
+	}
+	if column > len(runes)+1 {
+		column = len(runes) + 1
+	}
+	return position{Line: line - 1, Character: utf16Len(runes[:column-1])}
+}
+
+// utf16Len returns the number of UTF-16 code units of runes
+func utf16Len(runes []rune) int {
+	n := 0
+	for _, r := range runes {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// overlaps reports whether two ranges share a position
+func overlaps(a, b textRange) bool {
+	return !before(a.End, b.Start) && !before(b.End, a.Start)
+}
+
+// before reports whether position a comes before position b
+func before(a, b position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+// isSpace reports whether r separates words
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// splitLines splits text into lines without their line endings. The result
+// always has at least one line.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// uriToPath converts a file URI into a file path
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid document URI %s: %w", uri, err)
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported document URI %s: only file URIs are supported", uri)
+	}
+
+	path := u.Path
+	// Windows paths look like /C:/docs/index.md
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), nil
+}
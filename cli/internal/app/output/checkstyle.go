@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// checkstyleVersion is the Checkstyle report version CI parsers expect
+const checkstyleVersion = "4.3"
+
+// CheckstyleFormatter formats results as a Checkstyle XML report. Issues of
+// all checkers are grouped by file, and each issue records its checker and
+// rule in the source attribute.
+type CheckstyleFormatter struct{}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// NewCheckstyleFormatter creates a new Checkstyle formatter
+func NewCheckstyleFormatter() *CheckstyleFormatter {
+	return &CheckstyleFormatter{}
+}
+
+// Format writes a single result as a Checkstyle report
+func (f *CheckstyleFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.FormatAll([]*models.Result{result}, w)
+}
+
+// FormatAll writes several results as one Checkstyle report
+func (f *CheckstyleFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	byFile := make(map[string][]checkstyleError)
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			byFile[issue.File] = append(byFile[issue.File], checkstyleError{
+				Line:     issue.Line,
+				Column:   issue.Column,
+				Severity: checkstyleSeverity(issue.Severity),
+				Message:  issue.Message,
+				Source:   "marvin." + result.Checker + "." + issue.Rule,
+			})
+		}
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	report := checkstyleReport{Version: checkstyleVersion}
+	for _, file := range files {
+		errors := byFile[file]
+		sort.SliceStable(errors, func(i, j int) bool {
+			if errors[i].Line != errors[j].Line {
+				return errors[i].Line < errors[j].Line
+			}
+			return errors[i].Column < errors[j].Column
+		})
+		report.Files = append(report.Files, checkstyleFile{Name: file, Errors: errors})
+	}
+
+	return writeXML(w, report)
+}
+
+// checkstyleSeverity maps a Marvin severity to a Checkstyle severity
+func checkstyleSeverity(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -1,8 +1,13 @@
 package output
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/svx/marvin/cli/internal/pkg/models"
 )
@@ -13,6 +18,135 @@ type Formatter interface {
 	Format(result *models.Result, w io.Writer) error
 }
 
+// MultiFormatter is implemented by formatters that write the results of
+// several checkers as one document, such as a SARIF log with one run per
+// checker. Formatters without it write each result in turn.
+type MultiFormatter interface {
+	// FormatAll writes all results to the writer
+	FormatAll(results []*models.Result, w io.Writer) error
+}
+
+// Options configure the formatters that need more than a format name
+type Options struct {
+	// Template is the template file or built-in template name of the
+	// template format
+	Template string
+
+	// Color enables colors in templates
+	Color bool
+}
+
+// formatters maps format names to formatter constructors
+var formatters = map[string]func(opts Options) (Formatter, error){
+	"text":       func(Options) (Formatter, error) { return NewPlainTextFormatter(), nil },
+	"json":       func(Options) (Formatter, error) { return NewJSONFormatter(), nil },
+	"sarif":      func(Options) (Formatter, error) { return NewSARIFFormatter(), nil },
+	"junit":      func(Options) (Formatter, error) { return NewJUnitFormatter(), nil },
+	"checkstyle": func(Options) (Formatter, error) { return NewCheckstyleFormatter(), nil },
+	"github":     func(Options) (Formatter, error) { return NewGitHubFormatter(os.Getenv("GITHUB_STEP_SUMMARY")), nil },
+	"html":       func(Options) (Formatter, error) { return NewHTMLFormatter("", DefaultSnippetLines), nil },
+	"markdown":   func(Options) (Formatter, error) { return NewMarkdownFormatter(DefaultMarkdownLimit), nil },
+	"template":   func(opts Options) (Formatter, error) { return NewTemplateFormatter(opts.Template, opts.Color) },
+}
+
+// NewFormatter returns the formatter for a format name
+func NewFormatter(name string, opts Options) (Formatter, error) {
+	newFormatter, ok := formatters[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (available: %s)", name, strings.Join(FormatNames(), ", "))
+	}
+	return newFormatter(opts)
+}
+
+// WriteFile writes results with the named format to a file, creating its
+// directory if needed
+func WriteFile(path, format string, opts Options, results []*models.Result) error {
+	formatter, err := NewFormatter(format, opts)
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
+	if err := FormatAll(formatter, results, file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// FormatNames returns the supported format names, sorted
+func FormatNames() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatAll writes results with f, as one document if f supports it
+func FormatAll(f Formatter, results []*models.Result, w io.Writer) error {
+	if multi, ok := f.(MultiFormatter); ok {
+		return multi.FormatAll(results, w)
+	}
+	for _, result := range results {
+		if err := f.Format(result, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// distinctCheckers returns an error if several results are of one checker.
+// Formatters that aggregate results per checker would drop all but one.
+func distinctCheckers(results []*models.Result) error {
+	seen := make(map[string]bool)
+	for _, result := range results {
+		if seen[result.Checker] {
+			return fmt.Errorf("several results of %s; the format shows one result per checker", result.Checker)
+		}
+		seen[result.Checker] = true
+	}
+	return nil
+}
+
+// JSONFormatter formats results as indented JSON
+type JSONFormatter struct{}
+
+// NewJSONFormatter creates a new JSON formatter
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
+
+// Format writes the result as a JSON object
+func (f *JSONFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.encode(result, w)
+}
+
+// FormatAll writes the results as a JSON array
+func (f *JSONFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	return f.encode(results, w)
+}
+
+func (f *JSONFormatter) encode(v interface{}, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
 // PlainTextFormatter formats results as plain text
 type PlainTextFormatter struct{}
 
@@ -54,7 +188,14 @@ func (f *PlainTextFormatter) Format(result *models.Result, w io.Writer) error {
 		}
 		fmt.Fprintf(w, ")")
 	}
-	fmt.Fprintf(w, "\n\n")
+	fmt.Fprintf(w, "\n")
+	if result.Summary.BaselinedCount > 0 {
+		fmt.Fprintf(w, "  Known Issues: %d (in baseline)\n", result.Summary.BaselinedCount)
+	}
+	if result.Summary.SuppressedCount > 0 {
+		fmt.Fprintf(w, "  Suppressed: %d (by marvin-disable comments)\n", result.Summary.SuppressedCount)
+	}
+	fmt.Fprintf(w, "\n")
 
 	// Issues
 	if len(result.Issues) > 0 {
@@ -66,7 +207,11 @@ func (f *PlainTextFormatter) Format(result *models.Result, w io.Writer) error {
 			fmt.Fprintf(w, "%s:%d:%d\n", issue.File, issue.Line, issue.Column)
 
 			// Severity and rule
-			fmt.Fprintf(w, "[%s] %s\n", issue.Severity, issue.Rule)
+			if issue.Baselined {
+				fmt.Fprintf(w, "[%s] %s (known)\n", issue.Severity, issue.Rule)
+			} else {
+				fmt.Fprintf(w, "[%s] %s\n", issue.Severity, issue.Rule)
+			}
 
 			// Message
 			fmt.Fprintf(w, "%s\n", issue.Message)
@@ -82,5 +227,16 @@ func (f *PlainTextFormatter) Format(result *models.Result, w io.Writer) error {
 		fmt.Fprintf(w, "No issues found! ✓\n\n")
 	}
 
+	// Suppressed issues (with --list-suppressed)
+	if len(result.Suppressed) > 0 {
+		fmt.Fprintf(w, "Suppressed Issues:\n")
+		fmt.Fprintf(w, "───────────────────────────────────────────────────────────\n\n")
+
+		for _, issue := range result.Suppressed {
+			fmt.Fprintf(w, "%s:%d:%d [%s] %s\n", issue.File, issue.Line, issue.Column, issue.Severity, issue.Rule)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
 	return nil
 }
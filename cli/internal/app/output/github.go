@@ -0,0 +1,203 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+const (
+	// githubAnnotationLimit is the number of annotations of each level that
+	// GitHub shows per step. Further issues are listed in the step summary.
+	githubAnnotationLimit = 10
+
+	// githubSummaryIssueLimit caps the issues listed in the step summary,
+	// which GitHub limits to 1 MiB
+	githubSummaryIssueLimit = 500
+)
+
+// GitHubFormatter writes issues as GitHub Actions workflow commands, which
+// GitHub shows as inline annotations on the changed files. A Markdown summary
+// with the issue counts and all issues beyond the annotation limit is
+// appended to the step summary file.
+type GitHubFormatter struct {
+	summaryPath string
+}
+
+// NewGitHubFormatter creates a new GitHub Actions formatter. summaryPath is
+// the step summary file, usually $GITHUB_STEP_SUMMARY; if it is empty no
+// summary is written.
+func NewGitHubFormatter(summaryPath string) *GitHubFormatter {
+	return &GitHubFormatter{
+		summaryPath: summaryPath,
+	}
+}
+
+// IsGitHubActions reports whether Marvin is running in GitHub Actions
+func IsGitHubActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// Format writes annotations and the step summary for a single result
+func (f *GitHubFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.FormatAll([]*models.Result{result}, w)
+}
+
+// FormatAll writes annotations for the results of several checkers, up to
+// the annotation limit per level, and appends the step summary. Known issues
+// from the baseline are not annotated, so that they cannot use up the limit
+// and hide new issues; the summary only counts them.
+func (f *GitHubFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	annotated := make(map[string]int)
+	var remaining []githubIssue
+	known := 0
+
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			if issue.Baselined {
+				known++
+				continue
+			}
+			level := githubLevel(issue.Severity)
+			if annotated[level] >= githubAnnotationLimit {
+				remaining = append(remaining, githubIssue{checker: result.Checker, Issue: issue})
+				continue
+			}
+			annotated[level]++
+			fmt.Fprintln(w, githubAnnotation(result.Checker, issue))
+		}
+	}
+
+	if f.summaryPath == "" {
+		if len(remaining) > 0 {
+			fmt.Fprintf(w, "::notice title=Marvin::%d more issues were not annotated\n", len(remaining))
+		}
+		if known > 0 {
+			fmt.Fprintf(w, "::notice title=Marvin::%d known issues from the baseline were not annotated\n", known)
+		}
+		return nil
+	}
+
+	file, err := os.OpenFile(f.summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open step summary: %w", err)
+	}
+	defer file.Close()
+
+	writeGitHubSummary(file, results, remaining, known)
+	return file.Close()
+}
+
+// githubIssue is an issue together with the checker that reported it
+type githubIssue struct {
+	checker string
+	models.Issue
+}
+
+// githubAnnotation formats an issue as a workflow command
+func githubAnnotation(checker string, issue models.Issue) string {
+	props := []string{"file=" + escapeGitHubProperty(issue.File)}
+	if issue.Line > 0 {
+		props = append(props, fmt.Sprintf("line=%d", issue.Line))
+		if issue.Column > 0 {
+			props = append(props, fmt.Sprintf("col=%d", issue.Column))
+		}
+	}
+	props = append(props, "title="+escapeGitHubProperty(githubTitle(checker, issue.Rule)))
+
+	return fmt.Sprintf("::%s %s::%s", githubLevel(issue.Severity), strings.Join(props, ","), escapeGitHubData(issue.Message))
+}
+
+// writeGitHubSummary writes the Markdown step summary
+func writeGitHubSummary(w io.Writer, results []*models.Result, remaining []githubIssue, known int) {
+	fmt.Fprintf(w, "## Marvin Results\n\n")
+	fmt.Fprintf(w, "| Checker | Files | Files with Issues | Errors | Warnings | Info |\n")
+	fmt.Fprintf(w, "| --- | ---: | ---: | ---: | ---: | ---: |\n")
+
+	var total models.Summary
+	for _, result := range results {
+		s := result.Summary
+		fmt.Fprintf(w, "| %s | %d | %d | %d | %d | %d |\n",
+			escapeMarkdownCell(result.Checker), s.TotalFiles, s.FilesWithIssues, s.ErrorCount, s.WarningCount, s.InfoCount)
+		total.TotalIssues += s.TotalIssues
+		total.ErrorCount += s.ErrorCount
+		total.WarningCount += s.WarningCount
+		total.InfoCount += s.InfoCount
+	}
+	if len(results) > 1 {
+		fmt.Fprintf(w, "| **Total** | | | **%d** | **%d** | **%d** |\n", total.ErrorCount, total.WarningCount, total.InfoCount)
+	}
+	fmt.Fprintln(w)
+
+	if total.TotalIssues == 0 {
+		fmt.Fprintf(w, "No issues found! ✓\n\n")
+		return
+	}
+	if known > 0 {
+		fmt.Fprintf(w, "%d of these issues are known from the baseline and are not annotated.\n\n", known)
+	}
+	if len(remaining) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "<details>\n<summary>%d issues not shown as annotations</summary>\n\n", len(remaining))
+	fmt.Fprintf(w, "| Severity | Checker | Location | Rule | Message |\n")
+	fmt.Fprintf(w, "| --- | --- | --- | --- | --- |\n")
+	for i, issue := range remaining {
+		if i == githubSummaryIssueLimit {
+			fmt.Fprintf(w, "\n…and %d more. See the JSON results for the full list.\n", len(remaining)-i)
+			break
+		}
+		fmt.Fprintf(w, "| %s | %s | `%s:%d:%d` | %s | %s |\n",
+			issue.Severity,
+			escapeMarkdownCell(issue.checker),
+			strings.ReplaceAll(issue.File, "`", "'"), issue.Line, issue.Column,
+			escapeMarkdownCell(issue.Rule),
+			escapeMarkdownCell(issue.Message))
+	}
+	fmt.Fprintf(w, "\n</details>\n\n")
+}
+
+// githubLevel maps a Marvin severity to a workflow command
+func githubLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// githubTitle returns the annotation title for an issue
+func githubTitle(checker, rule string) string {
+	if rule == "" {
+		return "marvin " + checker
+	}
+	return fmt.Sprintf("marvin %s: %s", checker, rule)
+}
+
+// escapeGitHubData escapes the message of a workflow command
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
+
+// escapeMarkdownCell makes a value safe for a Markdown table cell
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r", "")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,255 @@
+package output
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/app/dashboard"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+const (
+	// DefaultHTMLTitle is the title of HTML reports
+	DefaultHTMLTitle = "Marvin Report"
+
+	// DefaultSnippetLines is the number of source lines shown before and
+	// after the line of an issue
+	DefaultSnippetLines = 2
+
+	// maxSnippetFileSize skips source snippets of larger files
+	maxSnippetFileSize = 5 << 20
+)
+
+// htmlAssets holds the template, styles and script of HTML reports, which
+// are inlined so that a report is a single file
+//
+//go:embed html/*
+var htmlAssets embed.FS
+
+// HTMLFormatter writes results as a self-contained HTML report with a tab
+// per checker, severity, rule and file filters and source snippets
+type HTMLFormatter struct {
+	title        string
+	snippetLines int
+}
+
+// NewHTMLFormatter creates a new HTML formatter. snippetLines is the number
+// of source lines shown around each issue; a negative value disables
+// snippets.
+func NewHTMLFormatter(title string, snippetLines int) *HTMLFormatter {
+	if title == "" {
+		title = DefaultHTMLTitle
+	}
+	return &HTMLFormatter{
+		title:        title,
+		snippetLines: snippetLines,
+	}
+}
+
+// Format writes a report for a single result
+func (f *HTMLFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.FormatAll([]*models.Result{result}, w)
+}
+
+// FormatAll writes a report for the results of several checkers
+func (f *HTMLFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	if err := distinctCheckers(results); err != nil {
+		return err
+	}
+	return f.FormatDashboard(dashboard.FromResults(results), w)
+}
+
+// FormatDashboard writes a report with the latest result of each checker
+// and the run statistics of the dashboard
+func (f *HTMLFormatter) FormatDashboard(data *models.DashboardData, w io.Writer) error {
+	tmpl, err := template.New("report.html").Funcs(template.FuncMap{
+		"severity": htmlSeverity,
+	}).ParseFS(htmlAssets, "html/report.html")
+	if err != nil {
+		return fmt.Errorf("failed to parse HTML template: %w", err)
+	}
+
+	styles, err := htmlAssets.ReadFile("html/report.css")
+	if err != nil {
+		return fmt.Errorf("failed to read HTML styles: %w", err)
+	}
+	script, err := htmlAssets.ReadFile("html/report.js")
+	if err != nil {
+		return fmt.Errorf("failed to read HTML script: %w", err)
+	}
+
+	report := htmlReport{
+		Title:     f.title,
+		Generated: time.Now(),
+		Summary:   dashboard.GetOverallSummary(data),
+		Styles:    template.CSS(styles),
+		Script:    template.JS(script),
+	}
+	sources := make(map[string][]string)
+	for _, stats := range data.Checkers {
+		result := data.LatestResults[stats.Name]
+		if result == nil {
+			continue
+		}
+		report.Checkers = append(report.Checkers, f.checker(stats, result, sources))
+	}
+
+	if err := tmpl.Execute(w, report); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
+
+// htmlReport is the data of the report template
+type htmlReport struct {
+	Title     string
+	Generated time.Time
+	Summary   models.Summary
+	Checkers  []htmlChecker
+	Styles    template.CSS
+	Script    template.JS
+}
+
+// htmlChecker is the tab of a checker
+type htmlChecker struct {
+	Stats  models.CheckerStats
+	Result *models.Result
+	Files  []htmlFile
+	Rules  []string
+}
+
+// htmlFile groups the issues of a file
+type htmlFile struct {
+	Path   string
+	Issues []htmlIssue
+}
+
+// htmlIssue is an issue with the source lines around it
+type htmlIssue struct {
+	models.Issue
+	Snippet []htmlLine
+}
+
+// htmlLine is a source line of a snippet
+type htmlLine struct {
+	Number int
+	Text   string
+	Marked bool
+}
+
+// checker groups the issues of a result by file, sorted by path and
+// position
+func (f *HTMLFormatter) checker(stats models.CheckerStats, result *models.Result, sources map[string][]string) htmlChecker {
+	tab := htmlChecker{Stats: stats, Result: result}
+
+	files := make(map[string]int)
+	rules := make(map[string]bool)
+	for _, issue := range result.Issues {
+		index, ok := files[issue.File]
+		if !ok {
+			index = len(tab.Files)
+			files[issue.File] = index
+			tab.Files = append(tab.Files, htmlFile{Path: issue.File})
+		}
+		tab.Files[index].Issues = append(tab.Files[index].Issues, htmlIssue{
+			Issue:   issue,
+			Snippet: f.snippet(issue, sources),
+		})
+		if issue.Rule != "" {
+			rules[issue.Rule] = true
+		}
+	}
+
+	sort.SliceStable(tab.Files, func(i, j int) bool {
+		return tab.Files[i].Path < tab.Files[j].Path
+	})
+	for _, file := range tab.Files {
+		sort.SliceStable(file.Issues, func(i, j int) bool {
+			if file.Issues[i].Line != file.Issues[j].Line {
+				return file.Issues[i].Line < file.Issues[j].Line
+			}
+			return file.Issues[i].Column < file.Issues[j].Column
+		})
+	}
+	for rule := range rules {
+		tab.Rules = append(tab.Rules, rule)
+	}
+	sort.Strings(tab.Rules)
+
+	return tab
+}
+
+// snippet returns the source lines around an issue, or nil if the file
+// cannot be read. Files are read once and kept in sources.
+func (f *HTMLFormatter) snippet(issue models.Issue, sources map[string][]string) []htmlLine {
+	if f.snippetLines < 0 || issue.Line < 1 {
+		return nil
+	}
+
+	lines, ok := sources[issue.File]
+	if !ok {
+		lines = readSourceLines(issue.File)
+		sources[issue.File] = lines
+	}
+	if issue.Line > len(lines) {
+		return nil
+	}
+
+	start := issue.Line - f.snippetLines
+	if start < 1 {
+		start = 1
+	}
+	end := issue.Line + f.snippetLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var snippet []htmlLine
+	for n := start; n <= end; n++ {
+		snippet = append(snippet, htmlLine{Number: n, Text: lines[n-1], Marked: n == issue.Line})
+	}
+	return snippet
+}
+
+// htmlSeverity maps a severity to the error, warning or info level used to
+// style and filter issues
+func htmlSeverity(severity string) string {
+	switch severity {
+	case "error", "warning":
+		return severity
+	default:
+		return "info"
+	}
+}
+
+// readSourceLines returns the lines of a source file, or nil if it cannot
+// be read or is too large
+func readSourceLines(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err != nil || info.IsDir() || info.Size() > maxSnippetFileSize {
+		return nil
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxSnippetFileSize)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if scanner.Err() != nil {
+		return nil
+	}
+	return lines
+}
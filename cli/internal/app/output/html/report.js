@@ -0,0 +1,66 @@
+(function () {
+  "use strict";
+
+  // Switch to the tab with the given panel ID and remember it in the URL
+  function showTab(id) {
+    var panel = document.getElementById(id);
+    if (!panel || !panel.classList.contains("panel")) {
+      return;
+    }
+    document.querySelectorAll(".panel").forEach(function (p) {
+      p.classList.toggle("active", p === panel);
+    });
+    document.querySelectorAll(".tab").forEach(function (tab) {
+      tab.classList.toggle("active", tab.dataset.tab === id);
+    });
+    if (location.hash !== "#" + id) {
+      history.replaceState(null, "", "#" + id);
+    }
+  }
+
+  // Hide the issues of a panel that do not match its filters, and the files
+  // without visible issues
+  function applyFilters(panel) {
+    var severity = panel.querySelector('[data-filter="severity"]').value;
+    var rule = panel.querySelector('[data-filter="rule"]').value;
+    var file = panel.querySelector('[data-filter="file"]').value.trim().toLowerCase();
+    var shown = 0;
+
+    panel.querySelectorAll(".file").forEach(function (group) {
+      var fileMatches = !file || group.dataset.file.toLowerCase().indexOf(file) !== -1;
+      var visible = 0;
+      group.querySelectorAll(".issue").forEach(function (issue) {
+        var match = fileMatches &&
+          (!severity || issue.dataset.severity === severity) &&
+          (!rule || issue.dataset.rule === rule);
+        issue.hidden = !match;
+        if (match) {
+          visible++;
+        }
+      });
+      group.hidden = visible === 0;
+      shown += visible;
+    });
+
+    panel.querySelector("[data-shown]").textContent = shown;
+  }
+
+  document.querySelectorAll("[data-tab]").forEach(function (el) {
+    el.addEventListener("click", function (event) {
+      event.preventDefault();
+      showTab(el.dataset.tab);
+    });
+  });
+
+  document.querySelectorAll(".panel").forEach(function (panel) {
+    panel.querySelectorAll("[data-filter]").forEach(function (input) {
+      input.addEventListener("input", function () {
+        applyFilters(panel);
+      });
+    });
+  });
+
+  if (location.hash) {
+    showTab(decodeURIComponent(location.hash.slice(1)));
+  }
+})();
@@ -0,0 +1,156 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// JUnitFormatter formats results as a JUnit XML report for CI systems such
+// as Jenkins and GitLab. Each checker is a test suite, each file with issues
+// is a test case and each issue is a failure of that test case.
+type JUnitFormatter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Time      string         `xml:"time,attr"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnitFormatter creates a new JUnit formatter
+func NewJUnitFormatter() *JUnitFormatter {
+	return &JUnitFormatter{}
+}
+
+// Format writes a single result as a JUnit report with one test suite
+func (f *JUnitFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.FormatAll([]*models.Result{result}, w)
+}
+
+// FormatAll writes several results as one JUnit report with a test suite
+// per checker
+func (f *JUnitFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	report := junitTestSuites{Name: "marvin"}
+	for _, result := range results {
+		suite := junitSuiteFor(result)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	return writeXML(w, report)
+}
+
+// junitSuiteFor converts the result of one checker to a test suite
+func junitSuiteFor(result *models.Result) junitTestSuite {
+	suite := junitTestSuite{
+		Name: result.Checker,
+		Time: "0",
+	}
+	if !result.Timestamp.IsZero() {
+		suite.Timestamp = result.Timestamp.Format("2006-01-02T15:04:05")
+	}
+
+	// Group issues by file, keeping the files sorted
+	byFile := make(map[string][]models.Issue)
+	for _, issue := range result.Issues {
+		byFile[issue.File] = append(byFile[issue.File], issue)
+	}
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		testCase := junitTestCase{
+			Name:      file,
+			ClassName: result.Checker,
+			Time:      "0",
+		}
+		for _, issue := range byFile[file] {
+			testCase.Failures = append(testCase.Failures, junitFailure{
+				Message: issue.Message,
+				Type:    issue.Rule,
+				Text:    junitFailureText(issue),
+			})
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	// A checker without issues still reports a passing test case, so CI
+	// systems show that it ran
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      result.Path,
+			ClassName: result.Checker,
+			Time:      "0",
+		})
+	}
+
+	suite.Tests = len(suite.Cases)
+	for _, testCase := range suite.Cases {
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+		}
+	}
+
+	return suite
+}
+
+// junitFailureText describes an issue in the body of a failure element
+func junitFailureText(issue models.Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:%d:%d\n", issue.File, issue.Line, issue.Column)
+	fmt.Fprintf(&b, "[%s] %s\n", issue.Severity, issue.Rule)
+	fmt.Fprintf(&b, "%s\n", issue.Message)
+	if issue.Context != "" {
+		fmt.Fprintf(&b, "Context: %s\n", issue.Context)
+	}
+	return b.String()
+}
+
+// writeXML writes v as an indented XML document with an XML declaration
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,345 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/app/diff"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+const (
+	// DefaultMarkdownLimit keeps Markdown reports below the 65536 character
+	// limit of GitHub comments, with room for text added around them
+	DefaultMarkdownLimit = 60000
+
+	// markdownReserve is kept free for the closing note of a truncated
+	// report
+	markdownReserve = 300
+)
+
+// markdownSeverityIcons mark the severity of issues
+var markdownSeverityIcons = map[string]string{
+	"error":   "🔴",
+	"warning": "🟡",
+	"info":    "🔵",
+}
+
+// MarkdownFormatter writes results as Markdown for pull request comments: a
+// summary table of all checkers and a collapsible section per file. Reports
+// longer than the limit are truncated, keeping the files with the most
+// severe issues.
+type MarkdownFormatter struct {
+	limit int
+}
+
+// NewMarkdownFormatter creates a new Markdown formatter. limit is the
+// maximum length of a report in bytes; 0 means no limit.
+func NewMarkdownFormatter(limit int) *MarkdownFormatter {
+	return &MarkdownFormatter{
+		limit: limit,
+	}
+}
+
+// Format writes a single result as Markdown
+func (f *MarkdownFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.FormatAll([]*models.Result{result}, w)
+}
+
+// FormatAll writes the results of several checkers as one Markdown report
+func (f *MarkdownFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	b := &markdownBuilder{limit: f.limit}
+
+	b.WriteString("## Marvin Results\n\n")
+	b.WriteString("| Checker | Files | Files with Issues | Errors | Warnings | Info |\n")
+	b.WriteString("| --- | ---: | ---: | ---: | ---: | ---: |\n")
+	var total models.Summary
+	for _, result := range results {
+		s := result.Summary
+		fmt.Fprintf(b, "| %s | %d | %d | %d | %d | %d |\n",
+			escapeMarkdownText(result.Checker), s.TotalFiles, s.FilesWithIssues, s.ErrorCount, s.WarningCount, s.InfoCount)
+		total.TotalIssues += s.TotalIssues
+		total.ErrorCount += s.ErrorCount
+		total.WarningCount += s.WarningCount
+		total.InfoCount += s.InfoCount
+	}
+	if len(results) > 1 {
+		fmt.Fprintf(b, "| **Total** | | | **%d** | **%d** | **%d** |\n", total.ErrorCount, total.WarningCount, total.InfoCount)
+	}
+	b.WriteString("\n")
+
+	if total.TotalIssues == 0 {
+		b.WriteString("No issues found! ✓\n")
+		return b.flush(w)
+	}
+
+	for _, result := range results {
+		if len(result.Issues) == 0 {
+			continue
+		}
+		b.section(fmt.Sprintf("### %s\n\n", escapeMarkdownText(result.Checker)))
+		for _, group := range markdownFiles(result.Issues) {
+			b.file(group, false)
+		}
+	}
+
+	return b.flush(w)
+}
+
+// FormatDiff writes the comparison of two results as Markdown, with the new
+// issues first and the fixed issues after them. Unchanged issues are only
+// counted.
+func (f *MarkdownFormatter) FormatDiff(report *diff.Report, w io.Writer) error {
+	b := &markdownBuilder{limit: f.limit}
+
+	fmt.Fprintf(b, "## Marvin Results: %s\n\n", escapeMarkdownText(report.Checker))
+	b.WriteString("| | Issues | Errors | Warnings | Info |\n")
+	b.WriteString("| --- | ---: | ---: | ---: | ---: |\n")
+	for _, side := range []struct {
+		name string
+		info diff.ResultInfo
+	}{{"Before", report.Old}, {"After", report.New}} {
+		s := side.info.Summary
+		fmt.Fprintf(b, "| %s | %d | %d | %d | %d |\n", side.name, s.TotalIssues, s.ErrorCount, s.WarningCount, s.InfoCount)
+	}
+	b.WriteString("\n")
+
+	if !report.HasChanges() {
+		fmt.Fprintf(b, "No new or fixed issues. %d issues are unchanged.\n", report.Summary.Unchanged)
+		return b.flush(w)
+	}
+	fmt.Fprintf(b, "**%d new**, %d fixed and %d unchanged issues.\n\n", report.Summary.New, report.Summary.Fixed, report.Summary.Unchanged)
+
+	var added, fixed []models.Issue
+	for _, change := range report.Issues {
+		switch change.Status {
+		case diff.StatusNew:
+			added = append(added, change.Issue)
+		case diff.StatusFixed:
+			fixed = append(fixed, change.Issue)
+		}
+	}
+
+	if len(added) > 0 {
+		b.section("### New Issues\n\n")
+		for _, group := range markdownFiles(added) {
+			b.file(group, true)
+		}
+	}
+	if len(fixed) > 0 {
+		b.section("### Fixed Issues\n\n")
+		for _, group := range markdownFiles(fixed) {
+			b.file(group, false)
+		}
+	}
+
+	return b.flush(w)
+}
+
+// markdownFile groups the issues of a file
+type markdownFile struct {
+	path   string
+	issues []models.Issue
+	errors int
+}
+
+// markdownFiles groups issues by file. Files with the most errors come
+// first, so that they survive truncation; the issues of a file are ordered
+// by severity and line.
+func markdownFiles(issues []models.Issue) []*markdownFile {
+	var files []*markdownFile
+	byPath := make(map[string]*markdownFile)
+	for _, issue := range issues {
+		path := filepath.ToSlash(issue.File)
+		file, ok := byPath[path]
+		if !ok {
+			file = &markdownFile{path: path}
+			byPath[path] = file
+			files = append(files, file)
+		}
+		file.issues = append(file.issues, issue)
+		if issue.Severity == "error" {
+			file.errors++
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].errors != files[j].errors {
+			return files[i].errors > files[j].errors
+		}
+		return files[i].path < files[j].path
+	})
+	for _, file := range files {
+		sort.SliceStable(file.issues, func(i, j int) bool {
+			a, b := file.issues[i], file.issues[j]
+			if severityRank(a.Severity) != severityRank(b.Severity) {
+				return severityRank(a.Severity) < severityRank(b.Severity)
+			}
+			return a.Line < b.Line
+		})
+	}
+	return files
+}
+
+// markdownBuilder builds a report that stays within a length limit. Once
+// the limit is reached, further issues are only counted and a note about
+// them closes the report.
+type markdownBuilder struct {
+	strings.Builder
+	limit int
+
+	// pending is a heading written before the next file that fits
+	pending string
+
+	// omitted counts the issues that did not fit
+	omitted int
+}
+
+// fits reports whether n more bytes fit into the report, keeping room for
+// the closing note
+func (b *markdownBuilder) fits(n int) bool {
+	return b.limit <= 0 || b.Len()+n+markdownReserve <= b.limit
+}
+
+// section starts a section with a heading, which is only written if one of
+// its files fits
+func (b *markdownBuilder) section(heading string) {
+	b.pending = heading
+}
+
+// file writes a collapsible section with the issues of a file. If not all
+// issues fit, the first ones are written and the rest are counted.
+func (b *markdownBuilder) file(file *markdownFile, open bool) {
+	attr := ""
+	if open {
+		attr = " open"
+	}
+	header := fmt.Sprintf("<details%s>\n<summary><code>%s</code> (%s)</summary>\n\n| | Line | Rule | Message |\n| --- | ---: | --- | --- |\n",
+		attr, escapeHTML(file.path), countIssues(file.issues))
+	footer := "\n</details>\n\n"
+
+	rows := make([]string, len(file.issues))
+	for i, issue := range file.issues {
+		rows[i] = markdownRow(issue)
+	}
+
+	if !b.fits(len(b.pending) + len(header) + len(rows[0]) + len(footer)) {
+		b.omitted += len(file.issues)
+		return
+	}
+
+	b.WriteString(b.pending)
+	b.pending = ""
+	b.WriteString(header)
+
+	for i, row := range rows {
+		rest := len(rows) - i
+		note := fmt.Sprintf("\n…and %s in this file.\n", plural(rest, "more issue"))
+		if i > 0 && !b.fits(len(row)+len(note)+len(footer)) {
+			b.WriteString(note)
+			b.omitted += rest
+			break
+		}
+		b.WriteString(row)
+	}
+	b.WriteString(footer)
+}
+
+// flush writes the report, closed with a note about the issues that did not
+// fit
+func (b *markdownBuilder) flush(w io.Writer) error {
+	if b.omitted > 0 {
+		fmt.Fprintf(b, "> [!NOTE]\n> This report was shortened: %s are not shown. See the full results in the CI logs or artifacts.\n",
+			plural(b.omitted, "more issue"))
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write Markdown: %w", err)
+	}
+	return nil
+}
+
+// markdownRow formats an issue as a table row, linking the rule to its
+// documentation
+func markdownRow(issue models.Issue) string {
+	icon, ok := markdownSeverityIcons[issue.Severity]
+	if !ok {
+		icon = markdownSeverityIcons["info"]
+	}
+
+	rule := escapeMarkdownText(issue.Rule)
+	if issue.RuleURL != "" && rule != "" {
+		rule = fmt.Sprintf("[%s](%s)", rule, strings.ReplaceAll(issue.RuleURL, ")", "%29"))
+	}
+
+	message := escapeMarkdownText(issue.Message)
+	if issue.Baselined {
+		message += " _(known)_"
+	}
+
+	return fmt.Sprintf("| %s | %d:%d | %s | %s |\n", icon, issue.Line, issue.Column, rule, message)
+}
+
+// countIssues describes the number of issues by severity, as in
+// "3 issues: 1 error, 2 warnings"
+func countIssues(issues []models.Issue) string {
+	var errors, warnings, info int
+	for _, issue := range issues {
+		switch issue.Severity {
+		case "error":
+			errors++
+		case "warning":
+			warnings++
+		default:
+			info++
+		}
+	}
+
+	var parts []string
+	if errors > 0 {
+		parts = append(parts, plural(errors, "error"))
+	}
+	if warnings > 0 {
+		parts = append(parts, plural(warnings, "warning"))
+	}
+	if info > 0 {
+		parts = append(parts, fmt.Sprintf("%d info", info))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// plural formats a count with a noun, adding an s if needed
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// severityRank orders severities from most to least severe
+func severityRank(severity string) int {
+	switch severity {
+	case "error":
+		return 0
+	case "warning":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// escapeMarkdownText makes a value safe for a table cell in Markdown that
+// is rendered with HTML, as in pull request comments
+func escapeMarkdownText(s string) string {
+	return escapeMarkdownCell(escapeHTML(s))
+}
+
+// escapeHTML escapes the characters that start HTML tags and entities
+func escapeHTML(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	return strings.ReplaceAll(s, ">", "&gt;")
+}
@@ -0,0 +1,301 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+const (
+	// sarifVersion is the SARIF specification version written by SARIFFormatter
+	sarifVersion = "2.1.0"
+
+	// sarifSchema is the JSON schema of SARIF 2.1.0
+	sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifInformationURI points SARIF consumers to the Marvin project
+	sarifInformationURI = "https://github.com/svx/marvin"
+
+	// sarifFingerprintKey names Marvin's issue fingerprint in partialFingerprints
+	sarifFingerprintKey = "marvinFingerprint/v1"
+
+	// sarifSourceRoot is the URI base ID that relative file paths are resolved against
+	sarifSourceRoot = "%SRCROOT%"
+)
+
+// SARIFFormatter formats results as a SARIF 2.1.0 log for GitHub code
+// scanning and other SARIF-aware tools. Each checker is written as its own
+// run with its own tool driver and rule metadata.
+type SARIFFormatter struct{}
+
+// sarifLog is the top-level SARIF document
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+
+	// BaselineState is "unchanged" for known issues from the baseline and
+	// "new" for the others
+	BaselineState string `json:"baselineState"`
+
+	// Suppressions mark issues hidden by marvin-disable comments
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind string `json:"kind"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	Snippet     *sarifMessage `json:"snippet,omitempty"`
+}
+
+// NewSARIFFormatter creates a new SARIF formatter
+func NewSARIFFormatter() *SARIFFormatter {
+	return &SARIFFormatter{}
+}
+
+// Format writes a single result as a SARIF log with one run
+func (f *SARIFFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.FormatAll([]*models.Result{result}, w)
+}
+
+// FormatAll writes several results as one SARIF log with a run per checker
+func (f *SARIFFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    make([]sarifRun, 0, len(results)),
+	}
+	for _, result := range results {
+		log.Runs = append(log.Runs, sarifRunFor(result))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF: %w", err)
+	}
+	return nil
+}
+
+// sarifRunFor converts the result of one checker to a SARIF run. Issues
+// suppressed with marvin-disable comments are included as suppressed
+// results if they were listed.
+func sarifRunFor(result *models.Result) sarifRun {
+	issues := append(append([]models.Issue{}, result.Issues...), result.Suppressed...)
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "marvin-" + result.Checker,
+				InformationURI: sarifInformationURI,
+				Rules:          sarifRules(issues),
+			},
+		},
+		Results: make([]sarifResult, 0, len(issues)),
+	}
+
+	ruleIndex := make(map[string]int, len(run.Tool.Driver.Rules))
+	for i, rule := range run.Tool.Driver.Rules {
+		ruleIndex[rule.ID] = i
+	}
+
+	for i, issue := range issues {
+		ruleID := sarifRuleID(issue)
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact(issue.File),
+			},
+		}
+		// SARIF lines and columns are 1-based; 0 means the checker did not report one
+		if issue.Line > 0 {
+			region := &sarifRegion{StartLine: issue.Line}
+			if issue.Column > 0 {
+				region.StartColumn = issue.Column
+			}
+			if issue.Context != "" {
+				region.Snippet = &sarifMessage{Text: issue.Context}
+			}
+			location.PhysicalLocation.Region = region
+		}
+
+		sarifIssue := sarifResult{
+			RuleID:        ruleID,
+			RuleIndex:     ruleIndex[ruleID],
+			Level:         sarifLevel(issue.Severity),
+			Message:       sarifMessage{Text: issue.Message},
+			Locations:     []sarifLocation{location},
+			BaselineState: "new",
+		}
+		if issue.Baselined {
+			sarifIssue.BaselineState = "unchanged"
+		}
+		if i >= len(result.Issues) {
+			sarifIssue.Suppressions = []sarifSuppression{{Kind: "inSource"}}
+		}
+		// Lets code scanning track issues across runs
+		if issue.Fingerprint != "" {
+			sarifIssue.PartialFingerprints = map[string]string{sarifFingerprintKey: issue.Fingerprint}
+		}
+		run.Results = append(run.Results, sarifIssue)
+	}
+
+	return run
+}
+
+// sarifRules returns the rule metadata for all rules used by the issues,
+// sorted by rule ID. The default level of a rule is its most severe issue.
+func sarifRules(issues []models.Issue) []sarifRule {
+	rules := make(map[string]*sarifRule)
+	for _, issue := range issues {
+		id := sarifRuleID(issue)
+		rule, ok := rules[id]
+		if !ok {
+			rule = &sarifRule{
+				ID:                   id,
+				Name:                 id,
+				ShortDescription:     sarifMessage{Text: issue.Message},
+				DefaultConfiguration: sarifConfiguration{Level: sarifLevel(issue.Severity)},
+			}
+			rules[id] = rule
+		}
+		if rule.HelpURI == "" {
+			rule.HelpURI = issue.RuleURL
+		}
+		if level := sarifLevel(issue.Severity); sarifLevelRank(level) > sarifLevelRank(rule.DefaultConfiguration.Level) {
+			rule.DefaultConfiguration.Level = level
+		}
+	}
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	sorted := make([]sarifRule, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, *rules[id])
+	}
+	return sorted
+}
+
+// sarifRuleID returns the rule ID of an issue, since SARIF requires one
+func sarifRuleID(issue models.Issue) string {
+	if issue.Rule == "" {
+		return "unknown"
+	}
+	return issue.Rule
+}
+
+// sarifLevel maps a Marvin severity to a SARIF level
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifLevelRank orders SARIF levels by severity
+func sarifLevelRank(level string) int {
+	switch level {
+	case "error":
+		return 2
+	case "warning":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sarifArtifact returns the artifact location of a file. Paths are made
+// relative to the working directory where possible, so code scanning can
+// map them to files in the repository.
+func sarifArtifact(file string) sarifArtifactLocation {
+	path := file
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				path = rel
+			}
+		}
+	}
+
+	if filepath.IsAbs(path) {
+		uri := filepath.ToSlash(path)
+		if !strings.HasPrefix(uri, "/") {
+			uri = "/" + uri
+		}
+		return sarifArtifactLocation{URI: "file://" + uri}
+	}
+	return sarifArtifactLocation{
+		URI:       filepath.ToSlash(filepath.Clean(path)),
+		URIBaseID: sarifSourceRoot,
+	}
+}
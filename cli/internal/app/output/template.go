@@ -0,0 +1,317 @@
+package output
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/svx/marvin/cli/internal/app/dashboard"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// builtinTemplates are the templates that can be selected by name instead
+// of a file
+//
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// ansiColors are the escape codes of the colors templates can use
+var ansiColors = map[string]string{
+	"bold":    "\033[1m",
+	"red":     "\033[31m",
+	"green":   "\033[32m",
+	"yellow":  "\033[33m",
+	"blue":    "\033[34m",
+	"magenta": "\033[35m",
+	"cyan":    "\033[36m",
+	"gray":    "\033[90m",
+}
+
+// ansiReset ends a color
+const ansiReset = "\033[0m"
+
+// TemplateFormatter writes results with a user-defined text/template. The
+// template is executed once with TemplateData for all results.
+type TemplateFormatter struct {
+	tmpl  *template.Template
+	color bool
+}
+
+// TemplateData is the data a template is executed with
+type TemplateData struct {
+	// Results are the results to format, one per checker
+	Results []*models.Result
+
+	// Dashboard aggregates the results. For "marvin dashboard" it holds
+	// the run statistics of all saved results.
+	Dashboard *models.DashboardData
+
+	// Summary adds up the summaries of Results
+	Summary models.Summary
+}
+
+// IssueGroup is a group of issues returned by the groupBy template function
+type IssueGroup struct {
+	Key    string
+	Issues []models.Issue
+}
+
+// IssueCounts is returned by the counts template function
+type IssueCounts struct {
+	Total    int
+	Errors   int
+	Warnings int
+	Info     int
+	Files    int
+}
+
+// NewTemplateFormatter creates a formatter from a built-in template, or from
+// a template file if name is not a built-in template. Built-in templates take
+// precedence, so a file named like one must be given as a path such as
+// ./summary. color enables the color template functions.
+func NewTemplateFormatter(name string, color bool) (*TemplateFormatter, error) {
+	if name == "" {
+		return nil, fmt.Errorf("the template format requires --template (a file or one of: %s)", strings.Join(TemplateNames(), ", "))
+	}
+
+	text, err := readBuiltinTemplate(name)
+	if err != nil {
+		text, err = os.ReadFile(name)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("template not found: %s (built-in templates: %s)", name, strings.Join(TemplateNames(), ", "))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+	}
+
+	f := &TemplateFormatter{color: color}
+	tmpl, err := template.New(filepath.Base(name)).Funcs(f.funcs()).Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	f.tmpl = tmpl
+
+	return f, nil
+}
+
+// readBuiltinTemplate returns the text of a built-in template. Names with a
+// path separator are always files.
+func readBuiltinTemplate(name string) ([]byte, error) {
+	if strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("not a built-in template: %s", name)
+	}
+	return builtinTemplates.ReadFile(path.Join("templates", name+".tmpl"))
+}
+
+// TemplateNames returns the names of the built-in templates, sorted
+func TemplateNames() []string {
+	entries, _ := builtinTemplates.ReadDir("templates")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".tmpl"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Format executes the template for a single result
+func (f *TemplateFormatter) Format(result *models.Result, w io.Writer) error {
+	return f.FormatAll([]*models.Result{result}, w)
+}
+
+// FormatAll executes the template for the results of several checkers
+func (f *TemplateFormatter) FormatAll(results []*models.Result, w io.Writer) error {
+	if err := distinctCheckers(results); err != nil {
+		return err
+	}
+	return f.execute(results, dashboard.FromResults(results), w)
+}
+
+// FormatDashboard executes the template for the latest result of each
+// checker, with the statistics of all runs in Dashboard
+func (f *TemplateFormatter) FormatDashboard(data *models.DashboardData, w io.Writer) error {
+	var results []*models.Result
+	for _, stats := range data.Checkers {
+		if result := data.LatestResults[stats.Name]; result != nil {
+			results = append(results, result)
+		}
+	}
+	return f.execute(results, data, w)
+}
+
+// execute runs the template
+func (f *TemplateFormatter) execute(results []*models.Result, data *models.DashboardData, w io.Writer) error {
+	templateData := TemplateData{
+		Results:   results,
+		Dashboard: data,
+	}
+	for _, result := range results {
+		s := result.Summary
+		templateData.Summary.TotalFiles += s.TotalFiles
+		templateData.Summary.FilesWithIssues += s.FilesWithIssues
+		templateData.Summary.TotalIssues += s.TotalIssues
+		templateData.Summary.ErrorCount += s.ErrorCount
+		templateData.Summary.WarningCount += s.WarningCount
+		templateData.Summary.InfoCount += s.InfoCount
+		templateData.Summary.BaselinedCount += s.BaselinedCount
+		templateData.Summary.SuppressedCount += s.SuppressedCount
+	}
+
+	if err := f.tmpl.Execute(w, templateData); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// funcs returns the helper functions available to templates
+func (f *TemplateFormatter) funcs() template.FuncMap {
+	return template.FuncMap{
+		"groupBy":       groupIssues,
+		"severity":      filterSeverity,
+		"atLeast":       filterAtLeast,
+		"counts":        countIssueTotals,
+		"rel":           relativePath,
+		"plural":        plural,
+		"upper":         strings.ToUpper,
+		"lower":         strings.ToLower,
+		"join":          func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"colorEnabled":  func() bool { return f.color },
+		"color":         f.colorize,
+		"bold":          func(text string) (string, error) { return f.colorize("bold", text) },
+		"severityColor": f.severityColor,
+	}
+}
+
+// colorize wraps text in a color if colors are enabled
+func (f *TemplateFormatter) colorize(name, text string) (string, error) {
+	code, ok := ansiColors[name]
+	if !ok {
+		return "", fmt.Errorf("unknown color %q", name)
+	}
+	if !f.color || text == "" {
+		return text, nil
+	}
+	return code + text + ansiReset, nil
+}
+
+// severityColor colors text by severity: errors red, warnings yellow and
+// everything else blue
+func (f *TemplateFormatter) severityColor(severity, text string) (string, error) {
+	switch severity {
+	case "error":
+		return f.colorize("red", text)
+	case "warning":
+		return f.colorize("yellow", text)
+	default:
+		return f.colorize("blue", text)
+	}
+}
+
+// groupIssues groups issues by "file", "rule" or "severity". Groups are
+// sorted by key, severities from errors to info.
+func groupIssues(key string, issues []models.Issue) ([]IssueGroup, error) {
+	var keyOf func(models.Issue) string
+	switch key {
+	case "file":
+		keyOf = func(issue models.Issue) string { return issue.File }
+	case "rule":
+		keyOf = func(issue models.Issue) string { return issue.Rule }
+	case "severity":
+		keyOf = func(issue models.Issue) string { return issue.Severity }
+	default:
+		return nil, fmt.Errorf("cannot group issues by %q (use file, rule or severity)", key)
+	}
+
+	var groups []IssueGroup
+	index := make(map[string]int)
+	for _, issue := range issues {
+		k := keyOf(issue)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, IssueGroup{Key: k})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if key == "severity" {
+			return severityRank(groups[i].Key) < severityRank(groups[j].Key)
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups, nil
+}
+
+// filterSeverity returns the issues with one of a comma-separated list of
+// severities. "info" matches every severity other than error and warning.
+func filterSeverity(severities string, issues []models.Issue) []models.Issue {
+	wanted := make(map[int]bool)
+	for _, severity := range strings.Split(severities, ",") {
+		wanted[severityRank(strings.TrimSpace(severity))] = true
+	}
+
+	filtered := []models.Issue{}
+	for _, issue := range issues {
+		if wanted[severityRank(issue.Severity)] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// filterAtLeast returns the issues that are at least as severe as severity
+func filterAtLeast(severity string, issues []models.Issue) []models.Issue {
+	filtered := []models.Issue{}
+	for _, issue := range issues {
+		if severityRank(issue.Severity) <= severityRank(severity) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// countIssueTotals counts issues by severity and the files they are in
+func countIssueTotals(issues []models.Issue) IssueCounts {
+	counts := IssueCounts{Total: len(issues)}
+	files := make(map[string]bool)
+	for _, issue := range issues {
+		files[issue.File] = true
+		switch issue.Severity {
+		case "error":
+			counts.Errors++
+		case "warning":
+			counts.Warnings++
+		default:
+			counts.Info++
+		}
+	}
+	counts.Files = len(files)
+	return counts
+}
+
+// relativePath returns a path relative to the working directory with
+// forward slashes, or the path itself if it is outside of it
+func relativePath(p string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(p)
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return filepath.ToSlash(p)
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(p)
+	}
+	return filepath.ToSlash(rel)
+}
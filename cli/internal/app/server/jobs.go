@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svx/marvin/cli/internal/app/checker"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// JobStatus is the state of a check job
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// maxFinishedJobs is the number of finished jobs kept for status polling
+const maxFinishedJobs = 100
+
+// ErrQueueFull is returned when no more jobs can be queued
+var ErrQueueFull = errors.New("job queue is full")
+
+// CheckRequest describes a check to run
+type CheckRequest struct {
+	Checker string   `json:"checker"`
+	Paths   []string `json:"paths,omitempty"`
+}
+
+// RunFunc runs a check, reporting its progress to progress, and returns its
+// result and the path of the saved result file
+type RunFunc func(ctx context.Context, req CheckRequest, progress checker.ProgressFunc) (*models.Result, string, error)
+
+// Job is a check run requested through the API
+type Job struct {
+	ID      string    `json:"id"`
+	Checker string    `json:"checker"`
+	Paths   []string  `json:"paths,omitempty"`
+	Status  JobStatus `json:"status"`
+	Error   string    `json:"error,omitempty"`
+
+	// ResultID identifies the saved result once the job succeeded
+	ResultID string          `json:"result_id,omitempty"`
+	Summary  *models.Summary `json:"summary,omitempty"`
+
+	// Progress is the latest progress event of the check
+	Progress *checker.ProgressEvent `json:"progress,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Queue runs check jobs in the background with a limited number of
+// workers
+type Queue struct {
+	run     RunFunc
+	workers int
+	pending chan *jobState
+
+	mu   sync.Mutex
+	jobs map[string]*jobState
+}
+
+// jobState is a job together with its progress events
+type jobState struct {
+	job    Job
+	events []checker.ProgressEvent
+
+	// changed is closed and replaced when the job changes
+	changed chan struct{}
+}
+
+// update notifies the subscribers of a job of a change
+func (s *jobState) update() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// finished reports whether the job is done
+func (s *jobState) finished() bool {
+	return s.job.Status == JobSucceeded || s.job.Status == JobFailed
+}
+
+// NewQueue creates a queue that runs up to workers jobs at a time and
+// holds up to size jobs waiting to run
+func NewQueue(run RunFunc, workers, size int) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	if size < 0 {
+		size = 0
+	}
+	return &Queue{
+		run:     run,
+		workers: workers,
+		pending: make(chan *jobState, size),
+		jobs:    make(map[string]*jobState),
+	}
+}
+
+// Start runs the workers until ctx is done. Running jobs are canceled with
+// ctx.
+func (q *Queue) Start(ctx context.Context) {
+	for i := 0; i < q.workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case state := <-q.pending:
+					q.execute(ctx, state)
+				}
+			}
+		}()
+	}
+}
+
+// Submit queues a check and returns its job
+func (q *Queue) Submit(req CheckRequest) (Job, error) {
+	state := &jobState{
+		job: Job{
+			ID:        newJobID(),
+			Checker:   req.Checker,
+			Paths:     req.Paths,
+			Status:    JobQueued,
+			CreatedAt: time.Now(),
+		},
+		changed: make(chan struct{}),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case q.pending <- state:
+	default:
+		return Job{}, ErrQueueFull
+	}
+	q.jobs[state.job.ID] = state
+	q.prune()
+
+	return state.job, nil
+}
+
+// Get returns a job by ID
+func (q *Queue) Get(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	state, ok := q.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return state.job, true
+}
+
+// Events returns the progress events of a job starting at index from, the
+// job itself and a channel that is closed when the job changes again
+func (q *Queue) Events(id string, from int) ([]checker.ProgressEvent, Job, <-chan struct{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	state, ok := q.jobs[id]
+	if !ok {
+		return nil, Job{}, nil, false
+	}
+
+	var events []checker.ProgressEvent
+	if from < len(state.events) {
+		events = append(events, state.events[from:]...)
+	}
+	return events, state.job, state.changed, true
+}
+
+// List returns all known jobs, newest first
+func (q *Queue) List() []Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := make([]Job, 0, len(q.jobs))
+	for _, state := range q.jobs {
+		jobs = append(jobs, state.job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	return jobs
+}
+
+// execute runs a job and records its progress and outcome
+func (q *Queue) execute(ctx context.Context, state *jobState) {
+	q.mu.Lock()
+	job := &state.job
+	started := time.Now()
+	job.Status = JobRunning
+	job.StartedAt = &started
+	req := CheckRequest{Checker: job.Checker, Paths: job.Paths}
+	state.update()
+	q.mu.Unlock()
+
+	result, outputPath, err := q.run(ctx, req, func(event checker.ProgressEvent) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		state.events = append(state.events, event)
+		job.Progress = &event
+		state.update()
+	})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	defer state.update()
+	if err != nil {
+		job.Status = JobFailed
+		job.Error = err.Error()
+		return
+	}
+	job.Status = JobSucceeded
+	job.ResultID = strings.TrimSuffix(filepath.Base(outputPath), filepath.Ext(outputPath))
+	job.Summary = &result.Summary
+}
+
+// prune forgets the oldest finished jobs beyond maxFinishedJobs
+func (q *Queue) prune() {
+	var finished []*Job
+	for _, state := range q.jobs {
+		if state.finished() {
+			finished = append(finished, &state.job)
+		}
+	}
+	if len(finished) <= maxFinishedJobs {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].FinishedAt.Before(*finished[j].FinishedAt)
+	})
+	for _, job := range finished[:len(finished)-maxFinishedJobs] {
+		delete(q.jobs, job.ID)
+	}
+}
+
+// newJobID returns a random job ID
+func newJobID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,424 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/app/checker"
+	"github.com/svx/marvin/cli/internal/app/dashboard"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// Default limits of the API
+const (
+	DefaultAddr      = "127.0.0.1:8080"
+	DefaultWorkers   = 2
+	DefaultQueueSize = 50
+
+	defaultPageSize = 20
+	maxBodySize     = 1 << 20
+)
+
+// resultIDPattern matches the IDs of saved results, which are file names
+var resultIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Options configure the API server
+type Options struct {
+	// Addr is the address the server listens on. Requests must name it or a
+	// loopback host in their Host header, so that DNS rebinding cannot reach
+	// the API; any host is accepted when it listens on all interfaces.
+	Addr string
+
+	// OutputDir is the directory results are read from
+	OutputDir string
+
+	// Jobs runs the checks requested through the API
+	Jobs *Queue
+
+	// Enabled reports whether a checker is enabled in .marvin.yaml
+	Enabled func(name string) bool
+
+	// AllowOrigin is sent as Access-Control-Allow-Origin, if set
+	AllowOrigin string
+}
+
+// Server serves results and check runs over HTTP
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// CheckerInfo describes a registered checker
+type CheckerInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Tool        string `json:"tool,omitempty"`
+	Plugin      string `json:"plugin,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// ResultsResponse is a page of saved results
+type ResultsResponse struct {
+	Results  []*models.Result `json:"results"`
+	Total    int              `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"pageSize"`
+}
+
+// errorResponse is the body of failed requests
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// New creates an API server
+func New(opts Options) *Server {
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /api/results", s.listResults)
+	s.mux.HandleFunc("GET /api/results/{id}", s.getResult)
+	s.mux.HandleFunc("GET /api/dashboard", s.getDashboard)
+	s.mux.HandleFunc("GET /api/checkers", s.listCheckers)
+	s.mux.HandleFunc("GET /api/checks", s.listChecks)
+	s.mux.HandleFunc("POST /api/checks", s.startCheck)
+	s.mux.HandleFunc("GET /api/checks/{id}", s.getCheck)
+	s.mux.HandleFunc("GET /api/checks/{id}/events", s.streamCheck)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler. Requests for another host, and browser
+// requests from origins other than the server itself and AllowOrigin, are
+// rejected.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedHost(r.Host) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("host not allowed: %s", r.Host))
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && !s.allowedOrigin(origin, r.Host) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("origin not allowed: %s", origin))
+		return
+	}
+
+	if s.opts.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.opts.AllowOrigin)
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// allowedHost reports whether the Host header of a request names the server
+func (s *Server) allowedHost(hostport string) bool {
+	host := hostname(hostport)
+	if host == "localhost" {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	listen := hostname(s.opts.Addr)
+	if listen == "" {
+		return true
+	}
+	if ip := net.ParseIP(listen); ip != nil && ip.IsUnspecified() {
+		return true
+	}
+	return strings.EqualFold(host, listen)
+}
+
+// allowedOrigin reports whether a browser request from origin may use the
+// API: it comes from the server itself or from AllowOrigin
+func (s *Server) allowedOrigin(origin, host string) bool {
+	if s.opts.AllowOrigin == "*" || origin == s.opts.AllowOrigin {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, host)
+}
+
+// hostname returns the host of a host:port address without the port
+func hostname(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.Trim(hostport, "[]")
+}
+
+// listResults returns saved results, newest first. The checker query
+// parameter filters by checker, limit and offset select a page.
+func (s *Server) listResults(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, err := queryInt(query.Get("limit"), defaultPageSize)
+	if err != nil || limit < 1 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %s", query.Get("limit")))
+		return
+	}
+	offset, err := queryInt(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid offset: %s", query.Get("offset")))
+		return
+	}
+
+	data, err := dashboard.LoadDashboardData(s.opts.OutputDir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	results := []*models.Result{}
+	for _, result := range data.AllResults {
+		if name := query.Get("checker"); name == "" || result.Checker == name {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Timestamp.After(results[j].Timestamp)
+	})
+
+	page := ResultsResponse{
+		Results:  []*models.Result{},
+		Total:    len(results),
+		Page:     offset/limit + 1,
+		PageSize: limit,
+	}
+	if offset < len(results) {
+		end := offset + limit
+		if end > len(results) {
+			end = len(results)
+		}
+		page.Results = results[offset:end]
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// getResult returns a saved result by ID
+func (s *Server) getResult(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !resultIDPattern.MatchString(id) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid result ID: %s", id))
+		return
+	}
+
+	result, err := dashboard.ParseResultFile(filepath.Join(s.opts.OutputDir, id+".json"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("result not found: %s", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// getDashboard returns the aggregated results of all checkers
+func (s *Server) getDashboard(w http.ResponseWriter, r *http.Request) {
+	data, err := dashboard.LoadDashboardData(s.opts.OutputDir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, data)
+}
+
+// listCheckers returns the registered checkers
+func (s *Server) listCheckers(w http.ResponseWriter, r *http.Request) {
+	infos := []CheckerInfo{}
+	for _, def := range checker.Definitions() {
+		infos = append(infos, CheckerInfo{
+			Name:        def.Name,
+			Description: def.Short,
+			Tool:        def.Tool,
+			Plugin:      def.Plugin,
+			Enabled:     s.opts.Enabled == nil || s.opts.Enabled(def.Name),
+		})
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+// listChecks returns the known check jobs, newest first
+func (s *Server) listChecks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.opts.Jobs.List())
+}
+
+// startCheck queues a check and returns its job. Paths must be inside the
+// working directory; without paths the checker scans its default path. The
+// body must be JSON, which browsers cannot send cross-origin without a CORS
+// preflight.
+func (s *Server) startCheck(w http.ResponseWriter, r *http.Request) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, fmt.Errorf("request body must be application/json"))
+		return
+	}
+
+	var body struct {
+		CheckRequest
+		// Path is accepted for a single path
+		Path string `json:"path"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	req := body.CheckRequest
+	if body.Path != "" {
+		req.Paths = append(req.Paths, body.Path)
+	}
+	if _, ok := checker.Lookup(req.Checker); !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown checker: %q", req.Checker))
+		return
+	}
+	for _, path := range req.Paths {
+		if err := checkPath(path); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	job, err := s.opts.Jobs.Submit(req)
+	if errors.Is(err, ErrQueueFull) {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/checks/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// getCheck returns a check job by ID
+func (s *Server) getCheck(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.opts.Jobs.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("check not found: %s", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// streamCheck streams the progress events of a check job as Server-Sent
+// Events. Each event is named after its kind and numbered, so that clients
+// can resume with Last-Event-ID. The stream ends with a "done" event that
+// holds the finished job.
+func (s *Server) streamCheck(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := s.opts.Jobs.Get(id); !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("check not found: %s", id))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+		return
+	}
+
+	next := 0
+	if last, err := strconv.Atoi(r.Header.Get("Last-Event-ID")); err == nil && last >= 0 {
+		next = last + 1
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		events, job, changed, ok := s.opts.Jobs.Events(id, next)
+		if !ok {
+			return
+		}
+		for _, event := range events {
+			writeEvent(w, strconv.Itoa(next), string(event.Kind), event)
+			next++
+		}
+		if job.Status == JobSucceeded || job.Status == JobFailed {
+			writeEvent(w, "", "done", job)
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEvent writes a Server-Sent Event with a JSON data field
+func writeEvent(w http.ResponseWriter, id, name string, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, body)
+}
+
+// checkPath returns an error if path does not exist or is outside the
+// working directory. Symlinks are resolved first, so that a link inside the
+// working directory cannot point a check outside of it.
+func checkPath(path string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if wd, err = filepath.EvalSymlinks(wd); err != nil {
+		return err
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("path does not exist: %s", path)
+	}
+	abs, err := filepath.Abs(resolved)
+	if err != nil {
+		return fmt.Errorf("invalid path: %s", path)
+	}
+	if rel, err := filepath.Rel(wd, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("path is outside the working directory: %s", path)
+	}
+	return nil
+}
+
+// queryInt parses an integer query parameter, returning fallback if it is
+// empty
+func queryInt(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,281 @@
+package suppress
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// Directives recognized in HTML comments:
+//
+//	<!-- marvin-disable [rules] -->            disable until marvin-enable
+//	<!-- marvin-enable [rules] -->             re-enable after marvin-disable
+//	<!-- marvin-disable-line [rules] -->       disable on the comment's line
+//	<!-- marvin-disable-next-line [rules] -->  disable on the following line
+//
+// Rules are rule IDs or checker names, separated by spaces or commas.
+// Without rules, a directive applies to all rules.
+const (
+	directiveDisable         = "disable"
+	directiveEnable          = "enable"
+	directiveDisableLine     = "disable-line"
+	directiveDisableNextLine = "disable-next-line"
+)
+
+// directivePattern matches a directive comment. The keyword must be followed
+// by whitespace or the end of the comment, so that marvin-disable-foo is not
+// read as marvin-disable for the rule -foo.
+var directivePattern = regexp.MustCompile(`<!--\s*marvin-(disable-next-line|disable-line|disable|enable)(?:\s+(.*?))?\s*-->`)
+
+// Apply removes the issues of result that are suppressed by inline comments
+// and counts them in Summary.SuppressedCount. If list is true, the removed
+// issues are kept in result.Suppressed. It returns the number of suppressed
+// issues.
+func Apply(result *models.Result, list bool) int {
+	files := make(map[string]*fileDirectives)
+
+	kept := make([]models.Issue, 0, len(result.Issues))
+	var suppressed []models.Issue
+	for _, issue := range result.Issues {
+		directives, ok := files[issue.File]
+		if !ok {
+			directives = parseFile(issue.File)
+			files[issue.File] = directives
+		}
+
+		if directives.suppresses(issue.Line, result.Checker, issue.Rule) {
+			suppressed = append(suppressed, issue)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+
+	if len(suppressed) == 0 {
+		return 0
+	}
+
+	result.Issues = kept
+	result.Summary.SuppressedCount += len(suppressed)
+	if list {
+		result.Suppressed = append(result.Suppressed, suppressed...)
+	}
+	result.Recount()
+
+	return len(suppressed)
+}
+
+// ruleSet is a set of rules; a nil set matches all rules
+type ruleSet map[string]bool
+
+func (s ruleSet) matches(checker, rule string) bool {
+	if s == nil {
+		return true
+	}
+	return s[strings.ToLower(rule)] || s[strings.ToLower(checker)]
+}
+
+// blockState is the set of rules disabled by marvin-disable at a line
+type blockState struct {
+	all      bool
+	disabled ruleSet
+	enabled  ruleSet
+}
+
+func (b *blockState) matches(checker, rule string) bool {
+	if b.all {
+		return b.enabled == nil || !b.enabled.matches(checker, rule)
+	}
+	return b.disabled != nil && b.disabled.matches(checker, rule)
+}
+
+// fileDirectives holds the suppression directives of one file
+type fileDirectives struct {
+	// blocks is the block state for each 1-based line
+	blocks []*blockState
+
+	// lines holds the rules suppressed on single lines
+	lines map[int][]ruleSet
+}
+
+// suppresses reports whether an issue of checker and rule at line is
+// suppressed. Issues without a line are treated as being on the first line.
+func (f *fileDirectives) suppresses(line int, checker, rule string) bool {
+	if f == nil {
+		return false
+	}
+	if line < 1 {
+		line = 1
+	}
+
+	for _, rules := range f.lines[line] {
+		if rules.matches(checker, rule) {
+			return true
+		}
+	}
+
+	if line <= len(f.blocks) {
+		if state := f.blocks[line-1]; state != nil && state.matches(checker, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFile reads the suppression directives of a file. It returns nil if
+// the file cannot be read or has no directives.
+func parseFile(path string) *fileDirectives {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	f := &fileDirectives{lines: make(map[int][]ruleSet)}
+	var state *blockState
+	found := false
+	fence := ""
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		// Comments in fenced code blocks are examples, not directives
+		if marker := fenceMarker(text); marker != "" {
+			switch {
+			case fence == "":
+				fence = marker
+			case strings.HasPrefix(marker, fence):
+				fence = ""
+			}
+		} else if fence == "" {
+			for _, match := range directivePattern.FindAllStringSubmatch(text, -1) {
+				found = true
+				rules := parseRules(match[2])
+				switch match[1] {
+				case directiveDisable:
+					state = state.disable(rules)
+				case directiveEnable:
+					state = state.enable(rules)
+				case directiveDisableLine:
+					f.lines[line] = append(f.lines[line], rules)
+				case directiveDisableNextLine:
+					f.lines[line+1] = append(f.lines[line+1], rules)
+				}
+			}
+		}
+
+		f.blocks = append(f.blocks, state)
+	}
+
+	if !found {
+		return nil
+	}
+	return f
+}
+
+// disable returns the state after a marvin-disable directive. States are
+// shared between lines, so they are copied rather than modified.
+func (b *blockState) disable(rules ruleSet) *blockState {
+	if rules == nil {
+		return &blockState{all: true}
+	}
+
+	next := b.copy()
+	if next.all {
+		for rule := range rules {
+			delete(next.enabled, rule)
+		}
+		return next
+	}
+	if next.disabled == nil {
+		next.disabled = make(ruleSet)
+	}
+	for rule := range rules {
+		next.disabled[rule] = true
+	}
+	return next
+}
+
+// enable returns the state after a marvin-enable directive
+func (b *blockState) enable(rules ruleSet) *blockState {
+	if rules == nil || b == nil {
+		return nil
+	}
+
+	next := b.copy()
+	if next.all {
+		if next.enabled == nil {
+			next.enabled = make(ruleSet)
+		}
+		for rule := range rules {
+			next.enabled[rule] = true
+		}
+		return next
+	}
+	for rule := range rules {
+		delete(next.disabled, rule)
+	}
+	if len(next.disabled) == 0 {
+		return nil
+	}
+	return next
+}
+
+func (b *blockState) copy() *blockState {
+	next := &blockState{}
+	if b == nil {
+		return next
+	}
+	next.all = b.all
+	next.disabled = copyRules(b.disabled)
+	next.enabled = copyRules(b.enabled)
+	return next
+}
+
+func copyRules(rules ruleSet) ruleSet {
+	if rules == nil {
+		return nil
+	}
+	copied := make(ruleSet, len(rules))
+	for rule := range rules {
+		copied[rule] = true
+	}
+	return copied
+}
+
+// parseRules parses the rule list of a directive. It returns nil, meaning
+// all rules, if the list is empty.
+func parseRules(list string) ruleSet {
+	fields := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil
+	}
+
+	rules := make(ruleSet, len(fields))
+	for _, field := range fields {
+		rules[strings.ToLower(field)] = true
+	}
+	return rules
+}
+
+// fenceMarker returns the fence of a Markdown code fence line, or an empty
+// string if the line is not a fence
+func fenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, char := range []string{"`", "~"} {
+		if strings.HasPrefix(trimmed, strings.Repeat(char, 3)) {
+			end := len(trimmed) - len(strings.TrimLeft(trimmed, char))
+			return trimmed[:end]
+		}
+	}
+	return ""
+}
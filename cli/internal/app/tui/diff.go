@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/svx/marvin/cli/internal/app/diff"
+)
+
+var (
+	// Diff status styles
+	newIssueStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("196"))
+
+	fixedIssueStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("42"))
+
+	unchangedIssueStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("243"))
+)
+
+// DiffModel represents the TUI model for comparing two results
+type DiffModel struct {
+	report        *diff.Report
+	showUnchanged bool
+	quitting      bool
+}
+
+// ShowDiff displays the comparison of two results in an interactive TUI
+func ShowDiff(report *diff.Report) error {
+	p := tea.NewProgram(DiffModel{report: report})
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m DiffModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		case "u":
+			m.showUnchanged = !m.showUnchanged
+		}
+	}
+	return m, nil
+}
+
+func (m DiffModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	help := "Press u to show unchanged issues, q to quit"
+	if m.showUnchanged {
+		help = "Press u to hide unchanged issues, q to quit"
+	}
+	return formatDiff(m.report, m.showUnchanged) + "\n" + footerStyle.Render(help)
+}
+
+// formatDiff formats the diff report for display
+func formatDiff(report *diff.Report, showUnchanged bool) string {
+	var b strings.Builder
+
+	// Title
+	title := fmt.Sprintf(" Marvin - %s Diff ", strings.Title(report.Checker))
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	// Summary section
+	b.WriteString(sectionStyle.Render("Summary"))
+	b.WriteString("\n")
+
+	summaryLines := []string{
+		fmt.Sprintf("%s %s",
+			summaryLabelStyle.Render("Old:"),
+			summaryValueStyle.Render(fmt.Sprintf("%s (%d issues)", report.Old.Timestamp.Format("2006-01-02 15:04:05"), report.Old.Summary.TotalIssues))),
+		fmt.Sprintf("%s %s",
+			summaryLabelStyle.Render("New:"),
+			summaryValueStyle.Render(fmt.Sprintf("%s (%d issues)", report.New.Timestamp.Format("2006-01-02 15:04:05"), report.New.Summary.TotalIssues))),
+		fmt.Sprintf("%s %s, %s, %s",
+			summaryLabelStyle.Render("Changes:"),
+			newIssueStyle.Render(fmt.Sprintf("%d new", report.Summary.New)),
+			fixedIssueStyle.Render(fmt.Sprintf("%d fixed", report.Summary.Fixed)),
+			unchangedIssueStyle.Render(fmt.Sprintf("%d unchanged", report.Summary.Unchanged))),
+	}
+	for _, line := range summaryLines {
+		b.WriteString("  " + line + "\n")
+	}
+
+	if !report.HasChanges() && !showUnchanged {
+		b.WriteString("\n")
+		b.WriteString(infoStyle.Render("  ✓ No changes"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Rule deltas
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("By Rule"))
+	b.WriteString("\n")
+	for _, delta := range report.Rules {
+		if delta.Added == 0 && delta.Fixed == 0 {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %-40s %4d → %-4d %s %s\n",
+			ruleStyle.Render(delta.Name), delta.Old, delta.New,
+			newIssueStyle.Render(fmt.Sprintf("+%d", delta.Added)),
+			fixedIssueStyle.Render(fmt.Sprintf("-%d", delta.Fixed))))
+	}
+
+	// Issues section
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("Issues"))
+	b.WriteString("\n")
+
+	first := true
+	for _, change := range report.Issues {
+		if change.Status == diff.StatusUnchanged && !showUnchanged {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+
+		// File location and status
+		location := fmt.Sprintf("%s:%d:%d", change.File, change.Line, change.Column)
+		b.WriteString("  " + diffStatusStyle(change.Status).Render(fmt.Sprintf("[%s]", change.Status)) + " " + fileLocationStyle.Render(location) + "\n")
+
+		// Severity and rule
+		severityStyle := getSeverityStyle(change.Severity)
+		b.WriteString("  " + severityStyle.Render(fmt.Sprintf("[%s]", change.Severity)) + " " + ruleStyle.Render(change.Rule) + "\n")
+
+		// Message
+		b.WriteString("  " + messageStyle.Render(change.Message) + "\n")
+	}
+
+	return b.String()
+}
+
+// diffStatusStyle returns the style for a diff status
+func diffStatusStyle(status diff.Status) lipgloss.Style {
+	switch status {
+	case diff.StatusNew:
+		return newIssueStyle
+	case diff.StatusFixed:
+		return fixedIssueStyle
+	default:
+		return unchangedIssueStyle
+	}
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/svx/marvin/cli/internal/pkg/models"
+)
+
+// LiveUpdate is a new state for the live results view
+type LiveUpdate struct {
+	// Results are the current results of all checkers
+	Results []*models.Result
+
+	// Running lists the files being checked. A non-empty list means a run
+	// has started and Results are those of the previous run.
+	Running []string
+
+	// Changed lists the files checked in the last run
+	Changed []string
+
+	// Err is the error of the last run, if any
+	Err error
+
+	// Time is when the update was made
+	Time time.Time
+}
+
+// ShowLiveResults displays results in a TUI that updates in place as new
+// results arrive on updates, until the user quits
+func ShowLiveResults(title string, updates <-chan LiveUpdate) error {
+	m := Model{
+		title:   title,
+		updates: updates,
+		ready:   false,
+	}
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// waitForUpdate returns a command that waits for the next live update
+func waitForUpdate(updates <-chan LiveUpdate) tea.Cmd {
+	return func() tea.Msg {
+		update, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return update
+	}
+}
+
+// formatLiveResults formats the results of several checkers as one list of
+// issues per file with a running total
+func formatLiveResults(title string, update LiveUpdate) string {
+	var b strings.Builder
+
+	// Title
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Marvin - %s ", title)))
+	b.WriteString("\n\n")
+
+	// Summary section
+	b.WriteString(sectionStyle.Render("Summary"))
+	b.WriteString("\n")
+
+	var total models.Summary
+	checkers := []string{}
+	files := make(map[string][]liveIssue)
+	checked := make(map[string]bool)
+	for _, result := range update.Results {
+		checkers = append(checkers, result.Checker)
+		total.TotalIssues += result.Summary.TotalIssues
+		total.ErrorCount += result.Summary.ErrorCount
+		total.WarningCount += result.Summary.WarningCount
+		total.InfoCount += result.Summary.InfoCount
+		for _, issue := range result.Issues {
+			file := filepath.Clean(issue.File)
+			files[file] = append(files[file], liveIssue{checker: result.Checker, Issue: issue})
+			checked[file] = true
+		}
+		for _, file := range result.CleanFiles {
+			checked[filepath.Clean(file)] = true
+		}
+	}
+
+	issuesParts := []string{}
+	if total.ErrorCount > 0 {
+		issuesParts = append(issuesParts, errorStyle.Render(fmt.Sprintf("%d errors", total.ErrorCount)))
+	}
+	if total.WarningCount > 0 {
+		issuesParts = append(issuesParts, warningStyle.Render(fmt.Sprintf("%d warnings", total.WarningCount)))
+	}
+	if total.InfoCount > 0 {
+		issuesParts = append(issuesParts, infoStyle.Render(fmt.Sprintf("%d suggestions", total.InfoCount)))
+	}
+	issuesSummary := fmt.Sprintf("%d", total.TotalIssues)
+	if len(issuesParts) > 0 {
+		issuesSummary += " (" + strings.Join(issuesParts, ", ") + ")"
+	}
+
+	status := fmt.Sprintf("last run %s", update.Time.Format("15:04:05"))
+	if len(update.Changed) > 0 {
+		status += fmt.Sprintf(", %d changed files", len(update.Changed))
+	}
+	if len(update.Running) > 0 {
+		status = fmt.Sprintf("checking %d changed files...", len(update.Running))
+	}
+
+	summaryLines := []string{
+		fmt.Sprintf("%s %s", summaryLabelStyle.Render("Checkers:"), summaryValueStyle.Render(strings.Join(checkers, ", "))),
+		fmt.Sprintf("%s %s", summaryLabelStyle.Render("Files Checked:"), summaryValueStyle.Render(fmt.Sprintf("%d", len(checked)))),
+		fmt.Sprintf("%s %s", summaryLabelStyle.Render("Files with Issues:"), summaryValueStyle.Render(fmt.Sprintf("%d", len(files)))),
+		fmt.Sprintf("%s %s", summaryLabelStyle.Render("Total Issues:"), summaryValueStyle.Render(issuesSummary)),
+		fmt.Sprintf("%s %s", summaryLabelStyle.Render("Status:"), summaryValueStyle.Render(status)),
+	}
+	if update.Err != nil {
+		summaryLines = append(summaryLines,
+			fmt.Sprintf("%s %s", summaryLabelStyle.Render("Error:"), errorStyle.Render(update.Err.Error())))
+	}
+	for _, line := range summaryLines {
+		b.WriteString("  " + line + "\n")
+	}
+
+	// Issues section
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("Issues"))
+	b.WriteString("\n")
+
+	if len(files) == 0 {
+		b.WriteString(infoStyle.Render("  ✓ No issues found!"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		issues := files[name]
+		sort.SliceStable(issues, func(i, j int) bool {
+			if issues[i].Line != issues[j].Line {
+				return issues[i].Line < issues[j].Line
+			}
+			return issues[i].Column < issues[j].Column
+		})
+
+		b.WriteString("  " + fileLocationStyle.Render(name) + " " + contextStyle.Render(fmt.Sprintf("(%d)", len(issues))) + "\n")
+		for _, issue := range issues {
+			severityStyle := getSeverityStyle(issue.Severity)
+			b.WriteString(fmt.Sprintf("    %s %s %s %s\n",
+				ruleStyle.Render(fmt.Sprintf("%d:%d", issue.Line, issue.Column)),
+				severityStyle.Render(fmt.Sprintf("[%s]", issue.Severity)),
+				ruleStyle.Render(issue.checker+"/"+issue.Rule),
+				messageStyle.Render(issue.Message)))
+		}
+	}
+
+	return b.String()
+}
+
+// liveIssue is an issue together with the checker that reported it
+type liveIssue struct {
+	checker string
+	models.Issue
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/svx/marvin/cli/internal/app/checker"
+)
+
+// spinnerFrames are the frames of the progress spinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// progressBarWidth is the width of a progress bar in cells
+const progressBarWidth = 30
+
+// progressTick advances the spinner
+type progressTick struct{}
+
+// progressDone is sent when the work finished
+type progressDone struct {
+	err error
+}
+
+// progressModel shows a spinner and a progress bar for each running checker
+type progressModel struct {
+	title    string
+	cancel   context.CancelFunc
+	frame    int
+	checkers []string
+	latest   map[string]checker.ProgressEvent
+	done     bool
+	err      error
+}
+
+// ShowProgress runs run and shows the progress it reports until it returns.
+// Pressing ctrl+c cancels the context passed to run.
+func ShowProgress(ctx context.Context, title string, run func(ctx context.Context, progress checker.ProgressFunc) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m := progressModel{
+		title:  title,
+		cancel: cancel,
+		latest: make(map[string]checker.ProgressEvent),
+	}
+	p := tea.NewProgram(m)
+
+	go func() {
+		err := run(ctx, func(event checker.ProgressEvent) {
+			p.Send(event)
+		})
+		p.Send(progressDone{err: err})
+	}()
+
+	final, err := p.Run()
+	if err != nil {
+		return err
+	}
+	return final.(progressModel).err
+}
+
+func (m progressModel) Init() tea.Cmd {
+	return tickProgress()
+}
+
+// tickProgress returns a command that advances the spinner
+func tickProgress() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return progressTick{}
+	})
+}
+
+func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			// Wait for the work to stop so that its error is returned
+			m.cancel()
+		}
+	case progressTick:
+		m.frame = (m.frame + 1) % len(spinnerFrames)
+		return m, tickProgress()
+	case checker.ProgressEvent:
+		if _, ok := m.latest[msg.Checker]; !ok {
+			m.checkers = append(m.checkers, msg.Checker)
+		}
+		m.latest[msg.Checker] = msg
+	case progressDone:
+		m.done = true
+		m.err = msg.err
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m progressModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(" Marvin - " + m.title + " "))
+	b.WriteString("\n\n")
+
+	width := 0
+	for _, name := range m.checkers {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+
+	for _, name := range m.checkers {
+		event := m.latest[name]
+
+		status := infoStyle.Render(spinnerFrames[m.frame])
+		switch event.Kind {
+		case checker.ProgressFinished:
+			status = infoStyle.Render("✓")
+		case checker.ProgressFailed:
+			status = errorStyle.Render("✗")
+		}
+
+		// Checkers that run an external tool report no files until they
+		// finish, so there is no bar to fill before the first file
+		bar := progressBar(event.Done, event.Total)
+		label := fmt.Sprintf("%d/%d files, %d issues", event.Done, event.Total, event.Issues)
+		if event.Kind == checker.ProgressStarted {
+			bar = strings.Repeat(" ", progressBarWidth)
+			label = fmt.Sprintf("checking %d files", event.Total)
+		}
+
+		line := fmt.Sprintf("  %s %-*s %s %s",
+			status,
+			width, name,
+			bar,
+			summaryLabelStyle.Render(label))
+		if event.Kind == checker.ProgressFailed {
+			line += " " + errorStyle.Render(event.Error)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if len(m.checkers) == 0 && !m.done {
+		b.WriteString(fmt.Sprintf("  %s %s\n", infoStyle.Render(spinnerFrames[m.frame]), summaryLabelStyle.Render("Starting...")))
+	}
+
+	return b.String()
+}
+
+// progressBar renders a bar that is done/total full
+func progressBar(done, total int) string {
+	filled := progressBarWidth
+	if total > 0 {
+		filled = progressBarWidth * done / total
+	}
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	return summaryValueStyle.Render(strings.Repeat("█", filled)) +
+		ruleStyle.Render(strings.Repeat("░", progressBarWidth-filled))
+}
@@ -8,12 +8,19 @@ import (
 	"github.com/svx/marvin/cli/internal/pkg/models"
 )
 
-// Model represents the TUI model
+// Model represents the TUI model. It shows a single result, or live
+// results that are updated in place when it has an updates channel.
 type Model struct {
 	result   *models.Result
 	content  string
 	ready    bool
 	quitting bool
+
+	// Live results
+	title   string
+	updates <-chan LiveUpdate
+	offset  int
+	height  int
 }
 
 // ShowResults displays the results in an interactive TUI
@@ -34,6 +41,9 @@ func initialModel(result *models.Result) Model {
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.updates != nil {
+		return waitForUpdate(m.updates)
+	}
 	return nil
 }
 
@@ -44,11 +54,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "q", "ctrl+c", "esc":
 			m.quitting = true
 			return m, tea.Quit
+		case "up", "k":
+			m.scroll(-1)
+		case "down", "j":
+			m.scroll(1)
+		case "pgup":
+			m.scroll(-m.pageSize())
+		case "pgdown", " ":
+			m.scroll(m.pageSize())
 		}
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.scroll(0)
+	case LiveUpdate:
+		m.content = formatLiveResults(m.title, msg)
+		m.ready = true
+		m.scroll(0)
+		return m, waitForUpdate(m.updates)
 	}
 	return m, nil
 }
 
+// pageSize returns the number of content lines that fit on the screen, or
+// zero if the content is not scrolled
+func (m Model) pageSize() int {
+	if m.updates == nil || m.height == 0 {
+		return 0
+	}
+	// Leave room for the footer
+	if size := m.height - 3; size > 0 {
+		return size
+	}
+	return 1
+}
+
+// scroll moves the visible part of live results by delta lines
+func (m *Model) scroll(delta int) {
+	size := m.pageSize()
+	if size == 0 {
+		return
+	}
+	maxOffset := strings.Count(m.content, "\n") - size
+	m.offset += delta
+	if m.offset > maxOffset {
+		m.offset = maxOffset
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
 func (m Model) View() string {
 	if m.quitting {
 		return ""
@@ -58,6 +113,19 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
+	if m.updates != nil {
+		content := m.content
+		if size := m.pageSize(); size > 0 {
+			lines := strings.Split(content, "\n")
+			end := m.offset + size
+			if end > len(lines) {
+				end = len(lines)
+			}
+			content = strings.Join(lines[m.offset:end], "\n")
+		}
+		return content + "\n" + footerStyle.Render("Watching for changes. Press ↑/↓ to scroll, q to quit")
+	}
+
 	return m.content + "\n" + footerStyle.Render("Press q to quit")
 }
 
@@ -111,6 +179,19 @@ func formatResults(result *models.Result) string {
 			summaryLabelStyle.Render("Total Issues:"),
 			summaryValueStyle.Render(issuesSummary)))
 
+	if result.Summary.BaselinedCount > 0 {
+		summaryLines = append(summaryLines,
+			fmt.Sprintf("%s %s",
+				summaryLabelStyle.Render("Known Issues:"),
+				summaryValueStyle.Render(fmt.Sprintf("%d (in baseline)", result.Summary.BaselinedCount))))
+	}
+	if result.Summary.SuppressedCount > 0 {
+		summaryLines = append(summaryLines,
+			fmt.Sprintf("%s %s",
+				summaryLabelStyle.Render("Suppressed:"),
+				summaryValueStyle.Render(fmt.Sprintf("%d (by marvin-disable comments)", result.Summary.SuppressedCount))))
+	}
+
 	for _, line := range summaryLines {
 		b.WriteString("  " + line + "\n")
 	}
@@ -137,7 +218,11 @@ func formatResults(result *models.Result) string {
 			// Severity and rule
 			severityStyle := getSeverityStyle(issue.Severity)
 			severityText := fmt.Sprintf("[%s]", issue.Severity)
-			b.WriteString("  " + severityStyle.Render(severityText) + " " + ruleStyle.Render(issue.Rule) + "\n")
+			rule := ruleStyle.Render(issue.Rule)
+			if issue.Baselined {
+				rule += " " + contextStyle.Render("(known)")
+			}
+			b.WriteString("  " + severityStyle.Render(severityText) + " " + rule + "\n")
 
 			// Message
 			b.WriteString("  " + messageStyle.Render(issue.Message) + "\n")
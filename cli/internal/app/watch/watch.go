@@ -0,0 +1,209 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long the watcher waits for more changes before
+// reporting a batch
+const DefaultDebounce = 300 * time.Millisecond
+
+// SkipFunc reports whether a path should not be watched
+type SkipFunc func(path string, isDir bool) bool
+
+// Watcher watches directory trees and reports changed files in debounced
+// batches. Directories created while watching are watched as well.
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	roots    []string
+	debounce time.Duration
+	skip     SkipFunc
+
+	changes chan []string
+	errors  chan error
+	done    chan struct{}
+}
+
+// New starts watching paths. Paths can be directories, which are watched
+// recursively, or files. skip may be nil.
+func New(paths []string, debounce time.Duration, skip SkipFunc) (*Watcher, error) {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	if skip == nil {
+		skip = func(string, bool) bool { return false }
+	}
+	w := &Watcher{
+		fs:       fs,
+		debounce: debounce,
+		skip:     skip,
+		changes:  make(chan []string),
+		errors:   make(chan error),
+		done:     make(chan struct{}),
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			fs.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+
+		w.roots = append(w.roots, filepath.Clean(path))
+		if !info.IsDir() {
+			// Editors often replace files on save, so watch the directory
+			if err := fs.Add(filepath.Dir(path)); err != nil {
+				fs.Close()
+				return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+			}
+			continue
+		}
+		if err := w.addTree(path, nil); err != nil {
+			fs.Close()
+			return nil, err
+		}
+	}
+
+	go w.loop()
+	return w, nil
+}
+
+// Changes returns the channel of changed file batches. A batch contains
+// each changed path once, including removed files.
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+// Errors returns the channel of watch errors
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.fs.Close()
+}
+
+// addTree watches dir and its subdirectories. If found is not nil, it is
+// called for each file in the tree.
+func (w *Watcher) addTree(dir string, found func(path string)) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// The directory may be gone again already
+			return nil
+		}
+		if !info.IsDir() {
+			if found != nil && !w.skip(path, false) {
+				found(path)
+			}
+			return nil
+		}
+		if path != dir && w.skipDir(path) {
+			return filepath.SkipDir
+		}
+		if err := w.fs.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// skipDir reports whether a directory is not watched. Hidden directories
+// and node_modules are skipped like in file discovery.
+func (w *Watcher) skipDir(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, ".") || name == "node_modules" || w.skip(path, true)
+}
+
+// loop collects events and sends them in batches once no new event arrived
+// for the debounce interval
+func (w *Watcher) loop() {
+	pending := make(map[string]bool)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			timer.Stop()
+			return
+
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if !w.inRoots(event.Name) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// Files may have been created before the directory
+					// was watched
+					if !w.skipDir(event.Name) {
+						w.addTree(event.Name, func(path string) {
+							pending[filepath.Clean(path)] = true
+						})
+						timer.Reset(w.debounce)
+					}
+					continue
+				}
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			if w.skip(event.Name, false) {
+				continue
+			}
+
+			pending[filepath.Clean(event.Name)] = true
+			timer.Reset(w.debounce)
+
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			select {
+			case w.errors <- err:
+			case <-w.done:
+				return
+			}
+
+		case <-timer.C:
+			batch := make([]string, 0, len(pending))
+			for path := range pending {
+				batch = append(batch, path)
+			}
+			sort.Strings(batch)
+			pending = make(map[string]bool)
+
+			select {
+			case w.changes <- batch:
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
+
+// inRoots reports whether path is one of the watched files or inside one of
+// the watched directories
+func (w *Watcher) inRoots(path string) bool {
+	path = filepath.Clean(path)
+	for _, root := range w.roots {
+		if path == root || root == "." || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
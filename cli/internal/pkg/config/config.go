@@ -0,0 +1,151 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFile is the config file Marvin looks for when --config is not set
+const DefaultConfigFile = ".marvin.yaml"
+
+// Config represents the contents of a .marvin.yaml file
+type Config struct {
+	OutputDir    string                   `yaml:"output_dir"`
+	Baseline     string                   `yaml:"baseline"`
+	Defaults     map[string]CheckerConfig `yaml:"defaults"`
+	TUI          TUIConfig                `yaml:"tui"`
+	Dependencies DependenciesConfig       `yaml:"dependencies"`
+	Gate         GateConfig               `yaml:"gate"`
+	Ignore       IgnoreConfig             `yaml:"ignore"`
+
+	// ListSuppressed keeps issues hidden by marvin-disable comments in the
+	// results for auditing
+	ListSuppressed bool `yaml:"list_suppressed"`
+}
+
+// CheckerConfig contains the default settings for a single checker
+type CheckerConfig struct {
+	Enabled       *bool  `yaml:"enabled"`
+	Path          string `yaml:"path"`
+	Config        string `yaml:"config"`
+	MinAlertLevel string `yaml:"min_alert_level"`
+	Glob          string `yaml:"glob"`
+	Fix           bool   `yaml:"fix"`
+
+	// Extensions overrides the file extensions the checker checks
+	Extensions []string `yaml:"extensions"`
+
+	// Options holds values for checker flags without a dedicated field,
+	// keyed by flag name
+	Options map[string]string `yaml:"options"`
+}
+
+// TUIConfig contains TUI settings
+type TUIConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// DependenciesConfig controls which sources are used to detect tools
+type DependenciesConfig struct {
+	CheckBrew   bool `yaml:"check_brew"`
+	CheckNpm    bool `yaml:"check_npm"`
+	CheckSystem bool `yaml:"check_system"`
+}
+
+// GateConfig contains the quality gate that decides when a check fails
+type GateConfig struct {
+	// FailOn is the lowest severity that fails the check: error, warning,
+	// info or none
+	FailOn string `yaml:"fail_on"`
+
+	// MaxErrors and MaxWarnings are budgets of allowed new issues
+	MaxErrors   *int `yaml:"max_errors"`
+	MaxWarnings *int `yaml:"max_warnings"`
+
+	// Rules and Paths limit the new issues per rule ID and per file or directory
+	Rules map[string]int `yaml:"rules"`
+	Paths map[string]int `yaml:"paths"`
+}
+
+// IgnoreConfig contains the settings for excluding files from all checkers
+type IgnoreConfig struct {
+	// Gitignore also applies the patterns in .gitignore files
+	Gitignore bool `yaml:"gitignore"`
+}
+
+// Default returns the configuration used when no config file is present
+func Default() *Config {
+	return &Config{
+		OutputDir: ".marvin/results",
+		Defaults:  make(map[string]CheckerConfig),
+		TUI: TUIConfig{
+			Enabled: true,
+		},
+		Dependencies: DependenciesConfig{
+			CheckBrew:   true,
+			CheckNpm:    true,
+			CheckSystem: true,
+		},
+	}
+}
+
+// Load reads the config file at path on top of the default configuration.
+// A missing file is only an error if required is true.
+func Load(path string, required bool) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	// Unknown keys are errors, so that typos do not go unnoticed
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if cfg.Defaults == nil {
+		cfg.Defaults = make(map[string]CheckerConfig)
+	}
+
+	return cfg, nil
+}
+
+// Checker returns the defaults for the named checker
+func (c *Config) Checker(name string) CheckerConfig {
+	return c.Defaults[name]
+}
+
+// IsEnabled reports whether the checker should run as part of `marvin check`.
+// Checkers are enabled unless explicitly disabled.
+func (c CheckerConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// Value returns the configured value for a checker flag, if any
+func (c CheckerConfig) Value(flag string) (string, bool) {
+	switch flag {
+	case "config":
+		return c.Config, c.Config != ""
+	case "min-alert-level":
+		return c.MinAlertLevel, c.MinAlertLevel != ""
+	case "glob":
+		return c.Glob, c.Glob != ""
+	case "fix":
+		return "true", c.Fix
+	}
+	value, ok := c.Options[flag]
+	return value, ok
+}
@@ -1,15 +1,30 @@
 package models
 
-import "time"
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
 
 // Result represents the output of a documentation QA check
 type Result struct {
+	// ID identifies a saved result by its file name without extension. It
+	// is only set for results loaded from the output directory.
+	ID string `json:"id,omitempty"`
+
 	Checker   string                 `json:"checker"`
 	Timestamp time.Time              `json:"timestamp"`
 	Path      string                 `json:"path"`
 	Summary   Summary                `json:"summary"`
 	Issues    []Issue                `json:"issues"`
 	Metadata  map[string]interface{} `json:"metadata"`
+
+	// CleanFiles lists the checked files without issues
+	CleanFiles []string `json:"clean_files,omitempty"`
+
+	// Suppressed lists the issues hidden by inline suppression comments.
+	// It is only filled when listing suppressed issues was requested.
+	Suppressed []Issue `json:"suppressed,omitempty"`
 }
 
 // Summary contains aggregate statistics about the check results
@@ -20,6 +35,13 @@ type Summary struct {
 	ErrorCount      int `json:"error_count"`
 	WarningCount    int `json:"warning_count"`
 	InfoCount       int `json:"info_count"`
+
+	// BaselinedCount is the number of issues that are known from the baseline
+	BaselinedCount int `json:"baselined_count,omitempty"`
+
+	// SuppressedCount is the number of issues hidden by inline suppression
+	// comments. Suppressed issues are not part of the other counts.
+	SuppressedCount int `json:"suppressed_count,omitempty"`
 }
 
 // Issue represents a single documentation issue found by a checker
@@ -31,4 +53,118 @@ type Issue struct {
 	Message  string `json:"message"`
 	Rule     string `json:"rule"`
 	Context  string `json:"context,omitempty"`
+	RuleURL  string `json:"rule_url,omitempty"`
+
+	// Fingerprint identifies the issue across runs. It does not depend on
+	// the line number, so it is stable when lines move.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Baselined marks a known issue recorded in the baseline file. Known
+	// issues are still reported but do not fail the check.
+	Baselined bool `json:"baselined,omitempty"`
+
+	// Fix is a change that resolves the issue, if the checker knows one
+	Fix *Fix `json:"fix,omitempty"`
+}
+
+// Fix describes how to resolve an issue by editing its file
+type Fix struct {
+	Description string `json:"description"`
+	Edits       []Edit `json:"edits"`
+}
+
+// Edit replaces the text between two positions of a file. Lines and columns
+// are 1-based and count characters; the end position is exclusive. An empty
+// range inserts NewText, an empty NewText deletes the range.
+type Edit struct {
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"end_line"`
+	EndColumn int    `json:"end_column"`
+	NewText   string `json:"new_text"`
+}
+
+// IssueKey identifies an issue of a checker independent of its position in
+// the file. It matches issues that have no fingerprint.
+type IssueKey struct {
+	File    string
+	Rule    string
+	Context string
+}
+
+// Key returns the position-independent key of the issue. Issues without
+// context text fall back to the message.
+func (i Issue) Key() IssueKey {
+	context := i.Context
+	if strings.TrimSpace(context) == "" {
+		context = i.Message
+	}
+	return IssueKey{
+		File:    NormalizePath(i.File),
+		Rule:    i.Rule,
+		Context: NormalizeText(context),
+	}
+}
+
+// NormalizePath makes file paths comparable across platforms and invocations
+func NormalizePath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// NormalizeText collapses whitespace so reflowed text compares equal
+func NormalizeText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Recount recalculates the issue counts in Summary from Issues.
+// TotalFiles is kept as reported by the checker, but never drops below
+// the number of files with issues.
+func (r *Result) Recount() {
+	files := make(map[string]bool)
+	r.Summary.TotalIssues = 0
+	r.Summary.ErrorCount = 0
+	r.Summary.WarningCount = 0
+	r.Summary.InfoCount = 0
+	r.Summary.BaselinedCount = 0
+
+	for _, issue := range r.Issues {
+		files[issue.File] = true
+		r.Summary.TotalIssues++
+		if issue.Baselined {
+			r.Summary.BaselinedCount++
+		}
+
+		switch issue.Severity {
+		case "error":
+			r.Summary.ErrorCount++
+		case "warning":
+			r.Summary.WarningCount++
+		default:
+			r.Summary.InfoCount++
+		}
+	}
+
+	r.Summary.FilesWithIssues = len(files)
+	if r.Summary.TotalFiles < r.Summary.FilesWithIssues {
+		r.Summary.TotalFiles = r.Summary.FilesWithIssues
+	}
+}
+
+// SetFiles records the files that were checked: TotalFiles becomes the
+// number of files and CleanFiles lists those without issues
+func (r *Result) SetFiles(files []string) {
+	withIssues := make(map[string]bool)
+	for _, issue := range r.Issues {
+		withIssues[filepath.Clean(issue.File)] = true
+	}
+
+	r.CleanFiles = []string{}
+	for _, file := range files {
+		if !withIssues[filepath.Clean(file)] {
+			r.CleanFiles = append(r.CleanFiles, file)
+		}
+	}
+
+	r.Summary.TotalFiles = len(files)
+	r.Recount()
 }
@@ -3,6 +3,7 @@ import { exec } from 'child_process';
 import { promisify } from 'util';
 import path from 'path';
 import fs from 'fs';
+import { getAvailableCheckers } from '@/lib/checkers';
 
 const execAsync = promisify(exec);
 
@@ -10,9 +11,11 @@ export async function POST(request: NextRequest) {
   try {
     const { checker, path: checkPath } = await request.json();
 
-    if (!checker || !['vale', 'markdownlint'].includes(checker)) {
+    // The allowed checkers come from the CLI's checker registry
+    const checkerNames = (await getAvailableCheckers()).map((c) => c.name);
+    if (!checker || !checkerNames.includes(checker)) {
       return NextResponse.json(
-        { error: 'Invalid checker type. Must be "vale" or "markdownlint"' },
+        { error: `Invalid checker type. Must be one of: ${checkerNames.join(', ')}` },
         { status: 400 }
       );
     }
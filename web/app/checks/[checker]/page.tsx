@@ -129,7 +129,7 @@ export default function CheckerDetailPage({ params }: { params: { checker: strin
           </div>
           <div className="flex gap-2">
             <RunCheckButton
-              checker={result.checker as 'vale' | 'markdownlint'}
+              checker={result.checker}
               onSuccess={handleCheckComplete}
               variant="secondary"
               size="sm"
@@ -137,7 +137,7 @@ export default function CheckerDetailPage({ params }: { params: { checker: strin
               label={`Re-run (${displayName})`}
             />
             <RunCheckButton
-              checker={result.checker as 'vale' | 'markdownlint'}
+              checker={result.checker}
               onSuccess={handleCheckComplete}
               variant="primary"
               size="sm"
@@ -4,7 +4,7 @@ import { useState } from 'react';
 import { runCheck } from '@/lib/api';
 
 interface RunCheckButtonProps {
-  checker: 'vale' | 'markdownlint';
+  checker: string;
   onSuccess?: () => void;
   variant?: 'primary' | 'secondary';
   size?: 'sm' | 'md' | 'lg';
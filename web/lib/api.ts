@@ -24,7 +24,7 @@ export async function fetchResultById(id: string): Promise<ResultWithId> {
 }
 
 export async function runCheck(
-  checker: 'vale' | 'markdownlint',
+  checker: string,
   path?: string
 ): Promise<{ success: boolean; message: string }> {
   const response = await fetch('/api/run-check', {
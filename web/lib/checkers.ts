@@ -0,0 +1,33 @@
+import { exec } from 'child_process';
+import { promisify } from 'util';
+import path from 'path';
+
+const execAsync = promisify(exec);
+
+export interface CheckerInfo {
+  name: string;
+  description: string;
+  tool?: string;
+  enabled: boolean;
+}
+
+let checkersPromise: Promise<CheckerInfo[]> | null = null;
+
+// getAvailableCheckers returns the checkers registered in the CLI.
+// The list is read once from `marvin checkers --json` and cached for the
+// lifetime of the server process.
+export function getAvailableCheckers(): Promise<CheckerInfo[]> {
+  if (!checkersPromise) {
+    const cliPath = path.join(path.resolve(process.cwd(), '..'), 'cli');
+    checkersPromise = execAsync(`cd ${cliPath} && go run main.go checkers --json`, {
+      timeout: 60000,
+    })
+      .then(({ stdout }) => JSON.parse(stdout) as CheckerInfo[])
+      .catch((error) => {
+        // Don't cache failures so the next request can retry
+        checkersPromise = null;
+        throw error;
+      });
+  }
+  return checkersPromise;
+}